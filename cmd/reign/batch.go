@@ -0,0 +1,332 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/bubbles/progress"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sovereynai/reign/internal/batch"
+	"github.com/sovereynai/reign/internal/budget"
+	"github.com/sovereynai/reign/internal/client"
+	"github.com/sovereynai/reign/internal/estimate"
+	"github.com/sovereynai/reign/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+func createBatchCommand() *cobra.Command {
+	batchCmd := &cobra.Command{
+		Use:   "batch",
+		Short: "Run many prompts from a file",
+	}
+
+	runCmd := &cobra.Command{
+		Use:   "run <input.jsonl>",
+		Short: "Run every prompt in a JSON Lines file",
+		Long: `Send every item of a JSON Lines file to throne and write the results to
+another. Each input line is an object with a "prompt" (or "messages") and
+optionally "id", "model", "system", "temperature" and "max_tokens".
+
+Results are written as they finish, with a checkpoint next to the output. If a
+run is interrupted, run the same command again to resume it; items that
+failed are retried too. The output is put in input order when the run ends.
+
+Example:
+  reign batch run prompts.jsonl -o results.jsonl
+  reign batch run prompts.jsonl -o results.jsonl -m qwen2.5:7b -c 8
+  reign batch run prompts.jsonl --dry-run
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: runBatch,
+	}
+	runCmd.Flags().StringP("output", "o", "", "Output file (default: <input>.out.jsonl)")
+	runCmd.Flags().StringP("model", "m", "llama3.2:3b", "Model for items that don't name one")
+	runCmd.Flags().IntP("concurrency", "c", 4, "Requests to run at once")
+	runCmd.Flags().Int("retries", 2, "Times to retry a failed item")
+	runCmd.Flags().Bool("restart", false, "Discard earlier output and checkpoint and start over")
+	runCmd.Flags().Bool("force", false, "Keep sending when over budget")
+	runCmd.Flags().Bool("dry-run", false, "Estimate the run's cost without sending anything")
+	runCmd.Flags().Bool("json", false, "Output the summary (or --dry-run estimate) as JSON")
+
+	batchCmd.AddCommand(runCmd)
+	return batchCmd
+}
+
+func runBatch(cmd *cobra.Command, args []string) error {
+	input := args[0]
+	output, _ := cmd.Flags().GetString("output")
+	model, _ := cmd.Flags().GetString("model")
+	concurrency, _ := cmd.Flags().GetInt("concurrency")
+	retries, _ := cmd.Flags().GetInt("retries")
+	restart, _ := cmd.Flags().GetBool("restart")
+	force, _ := cmd.Flags().GetBool("force")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	asJSON, _ := cmd.Flags().GetBool("json")
+
+	if output == "" {
+		output = strings.TrimSuffix(input, filepath.Ext(input)) + ".out.jsonl"
+	}
+	if concurrency < 1 {
+		return fmt.Errorf("concurrency must be at least 1")
+	}
+
+	items, err := batch.ReadItems(input)
+	if err != nil {
+		return err
+	}
+	if len(items) == 0 {
+		return fmt.Errorf("%s has no items", input)
+	}
+
+	c, err := getThroneClient()
+	if err != nil {
+		return err
+	}
+	if dryRun {
+		return estimateBatch(c, items, model, asJSON)
+	}
+
+	hash, err := batch.HashFile(input)
+	if err != nil {
+		return err
+	}
+	out, err := batch.OpenOutput(output, hash, restart)
+	if err != nil {
+		return err
+	}
+	if done, failed := out.Finished(); done > 0 && !asJSON {
+		fmt.Println(infoStyle.Render(ui.WithIcon(ui.Icon.Refresh,
+			fmt.Sprintf("Resuming: %d of %d items already done, %d failed items will be retried", done-failed, len(items), failed))))
+	}
+
+	var recordMu sync.Mutex // History and the spend ledger are appended to one at a time
+	runner := &batch.Runner{
+		Model:       model,
+		Concurrency: concurrency,
+		Retries:     retries,
+		Send: func(ctx context.Context, req client.ChatRequest) (*client.ChatResponse, error) {
+			start := time.Now()
+			resp, err := c.SendChatContext(ctx, req)
+			if ctx.Err() == nil {
+				recordMu.Lock()
+				recordRequest(req, resp, err, time.Since(start))
+				recordMu.Unlock()
+			}
+			return resp, err
+		},
+	}
+	if !force {
+		runner.Check = batchBudgetCheck(&pendingSpend{})
+	}
+
+	bar := newBatchProgress(len(items), out, !asJSON && isTerminal(os.Stderr))
+	runner.OnResult = bar.update
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	runErr := runner.Run(ctx, items, out)
+	bar.finish()
+
+	results, err := out.Finish(items)
+	if err != nil {
+		return err
+	}
+	summary := batch.Summarize(results)
+
+	if asJSON {
+		if err := writeJSON(summary); err != nil {
+			return err
+		}
+	} else {
+		printBatchSummary(summary, results, len(items), output)
+	}
+
+	switch {
+	case errors.Is(runErr, context.Canceled):
+		return fmt.Errorf("batch interrupted with %d of %d items done; run the same command to resume", summary.Succeeded, len(items))
+	case runErr != nil:
+		return fmt.Errorf("%w (%d of %d items done)", runErr, summary.Succeeded, len(items))
+	case summary.Failed > 0:
+		return fmt.Errorf("%d items failed; run the same command to retry them", summary.Failed)
+	}
+	return nil
+}
+
+// batchBudgetCheck fails items that are too expensive on their own, and stops
+// the run once a daily budget is used up. Items in flight count towards the
+// budgets with their estimated cost.
+func batchBudgetCheck(pending *pendingSpend) func(req client.ChatRequest) (func(), error) {
+	return func(req client.ChatRequest) (func(), error) {
+		release, err := pending.reserve(req)
+		var exceeded *budget.ExceededError
+		if !errors.As(err, &exceeded) {
+			return release, err
+		}
+		for _, u := range exceeded.Exceeded {
+			if u.Scope != budget.ScopePerRequest {
+				return nil, fmt.Errorf("%w: %v (raise the budget or pass --force, then run again to resume)", batch.ErrStop, err)
+			}
+		}
+		return nil, err
+	}
+}
+
+// batchProgress draws a progress bar on stderr as results come in
+type batchProgress struct {
+	enabled        bool
+	bar            progress.Model
+	total          int
+	done, failed   int
+	resumed        int
+	start          time.Time
+	lastLineLength int
+}
+
+func newBatchProgress(total int, out *batch.Output, enabled bool) *batchProgress {
+	done, failed := out.Finished()
+	p := &batchProgress{
+		enabled: enabled,
+		bar:     progress.New(progress.WithDefaultGradient(), progress.WithFillCharacters(ui.Icon.BarFull, ui.Icon.BarEmpty), progress.WithWidth(30)),
+		total:   total,
+		done:    done - failed,
+		resumed: done - failed,
+		start:   time.Now(),
+	}
+	p.draw()
+	return p
+}
+
+func (p *batchProgress) update(r batch.Result) {
+	if r.Error != "" {
+		p.failed++
+	} else {
+		p.done++
+	}
+	p.draw()
+}
+
+func (p *batchProgress) draw() {
+	if !p.enabled {
+		return
+	}
+	finished := p.done + p.failed
+	line := fmt.Sprintf("%s %d/%d", p.bar.ViewAs(float64(finished)/float64(p.total)), finished, p.total)
+	if p.failed > 0 {
+		line += " " + errorStyle.Render(fmt.Sprintf("%d failed", p.failed))
+	}
+	if sent := finished - p.resumed; sent > 0 {
+		elapsed := time.Since(p.start)
+		rate := float64(sent) / elapsed.Seconds()
+		eta := time.Duration(float64(p.total-finished) / rate * float64(time.Second))
+		line += fmt.Sprintf(" %.1f/s ETA %s", rate, eta.Round(time.Second))
+	}
+	fmt.Fprintf(os.Stderr, "\r%s%s", line, strings.Repeat(" ", max(0, p.lastLineLength-lipgloss.Width(line))))
+	p.lastLineLength = lipgloss.Width(line)
+}
+
+func (p *batchProgress) finish() {
+	if p.enabled {
+		fmt.Fprintln(os.Stderr)
+	}
+}
+
+func printBatchSummary(s batch.Summary, results []batch.Result, total int, output string) {
+	fmt.Println(titleStyle.Render(ui.WithIcon(ui.Icon.Chart, "Batch Summary")))
+	fmt.Println(infoStyle.Render("  Items:     ") + fmt.Sprintf("%d of %d succeeded, %d failed", s.Succeeded, total, s.Failed))
+	fmt.Println(infoStyle.Render("  Cost:      ") + fmt.Sprintf("%.4f credits", s.Cost))
+	if s.Succeeded > 0 {
+		fmt.Println(infoStyle.Render("  Latency:   ") + fmt.Sprintf("p50 %dms, p95 %dms, p99 %dms", s.P50LatencyMs, s.P95LatencyMs, s.P99LatencyMs))
+	}
+	fmt.Println(infoStyle.Render("  Output:    ") + output)
+
+	const maxShown = 5
+	var failed []batch.Result
+	for _, r := range results {
+		if r.Error != "" {
+			failed = append(failed, r)
+		}
+	}
+	if len(failed) > 0 {
+		fmt.Println()
+		fmt.Println(errorStyle.Render(ui.WithIcon(ui.Icon.Fail, "Failures")))
+		for _, r := range failed[:min(len(failed), maxShown)] {
+			fmt.Printf("  %s  %s\n", r.ID, r.Error)
+		}
+		if len(failed) > maxShown {
+			fmt.Println(infoStyle.Render(fmt.Sprintf("  ...and %d more", len(failed)-maxShown)))
+		}
+	}
+}
+
+// batchEstimate is the estimated cost of a batch's items for one model
+type batchEstimate struct {
+	Model   string         `json:"model"`
+	Items   int            `json:"items"`
+	Cost    estimate.Range `json:"cost"`
+	Unknown int            `json:"unknown"` // Items whose cost couldn't be estimated
+	Source  string         `json:"pricing_source"`
+}
+
+// estimateBatch estimates every item without sending any. Model information
+// is fetched once per model.
+func estimateBatch(c *client.ThroneClient, items []batch.Item, defaultModel string, asJSON bool) error {
+	byModel := make(map[string]*batchEstimate)
+	inputs := make(map[string]estimate.Input)
+	var total estimate.Range
+	for _, it := range items {
+		req := it.Request(defaultModel)
+		in, ok := inputs[req.Model]
+		if !ok {
+			in = estimateInput(c, req)
+			inputs[req.Model] = in
+			byModel[req.Model] = &batchEstimate{Model: req.Model}
+		}
+		in.Request = req
+		e := estimate.Make(in)
+
+		be := byModel[req.Model]
+		be.Items++
+		be.Source = e.Source
+		if e.Source == estimate.SourceUnknown {
+			be.Unknown++
+			continue
+		}
+		be.Cost.Low += e.Cost.Low
+		be.Cost.High += e.Cost.High
+		total.Low += e.Cost.Low
+		total.High += e.Cost.High
+	}
+
+	var models []batchEstimate
+	for _, name := range slices.Sorted(maps.Keys(byModel)) {
+		models = append(models, *byModel[name])
+	}
+
+	if asJSON {
+		return writeJSON(map[string]any{"items": len(items), "cost": total, "models": models})
+	}
+
+	fmt.Println(titleStyle.Render(ui.WithIcon(ui.Icon.Target, "Batch Cost Estimate (dry run, nothing sent)")))
+	for _, m := range models {
+		cost := fmt.Sprintf("%.4f-%.4f credits (from %s)", m.Cost.Low, m.Cost.High, pricingSourceText(m.Source))
+		if m.Source == estimate.SourceLocal {
+			cost = successStyle.Render("free (runs locally)")
+		}
+		if m.Unknown > 0 {
+			cost = fmt.Sprintf("unknown for %d items", m.Unknown)
+		}
+		fmt.Printf("  %s  %s  %s\n", infoStyle.Render(fmt.Sprintf("%-20s", m.Model)), fmt.Sprintf("%5d items", m.Items), cost)
+	}
+	fmt.Println()
+	fmt.Println(infoStyle.Render("  Total: ") + fmt.Sprintf("%d items, %.4f-%.4f credits", len(items), total.Low, total.High))
+	return nil
+}
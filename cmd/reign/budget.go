@@ -0,0 +1,302 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sovereynai/reign/internal/budget"
+	"github.com/sovereynai/reign/internal/client"
+	"github.com/sovereynai/reign/internal/config"
+	"github.com/sovereynai/reign/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// estimateWindow is how far back request costs are looked at for estimates
+const estimateWindow = 7 * 24 * time.Hour
+
+func createBudgetCommand() *cobra.Command {
+	budgetCmd := &cobra.Command{
+		Use:   "budget",
+		Short: "Local credit spending limits",
+		Long: `Budgets stop requests before they are sent when they would take spending
+over a limit. Limits apply per day (resetting at local midnight), per model,
+per project and per request, and are tracked on this machine.
+
+A request's cost is estimated from recent requests to the same model. Warnings
+are shown once a budget is 80% used (see warn-at). Pass --force to chat,
+replay or the playground to send a request anyway.
+
+The project is $REIGN_PROJECT, or else the name of the current directory.`,
+	}
+
+	statusCmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show today's spending against each budget",
+		RunE:  runBudgetStatus,
+	}
+	statusCmd.Flags().Bool("json", false, "Output budgets as JSON")
+
+	setCmd := &cobra.Command{
+		Use:   "set <daily|per-request|warn-at> <amount> | set <model|project> <name> <amount>",
+		Short: "Set or remove a budget",
+		Long: `Set a budget in credits. An amount of 0 or "off" removes it.
+
+Example:
+  reign budget set daily 5
+  reign budget set per-request 0.05
+  reign budget set model llama3.2:70b 2
+  reign budget set project my-app 1
+  reign budget set warn-at 0.9
+  reign budget set daily off
+`,
+		Args: cobra.RangeArgs(2, 3),
+		RunE: runBudgetSet,
+	}
+
+	budgetCmd.AddCommand(statusCmd, setCmd)
+	return budgetCmd
+}
+
+func runBudgetStatus(cmd *cobra.Command, args []string) error {
+	asJSON, _ := cmd.Flags().GetBool("json")
+
+	settings, err := config.LoadSettings()
+	if err != nil {
+		return err
+	}
+	today, err := budget.DefaultLedger().Since(budget.Today(time.Now()))
+	if err != nil {
+		return err
+	}
+	usage := budget.Status(settings.Budget, today)
+
+	if asJSON {
+		if usage == nil {
+			usage = []budget.Usage{}
+		}
+		return writeJSON(map[string]any{
+			"project":     budget.CurrentProject(),
+			"budgets":     usage,
+			"per_request": settings.Budget.PerRequest,
+		})
+	}
+
+	// Account credits are a bonus; budgets work without throne
+	var credits *client.CreditStats
+	if c, err := getThroneClient(); err == nil {
+		if stats, err := c.GetDashboardStats(); err == nil && stats.Developer != nil {
+			credits = &stats.Developer.Credits
+		}
+	}
+
+	fmt.Println(ui.RenderBudget(usage, settings.Budget.PerRequest, credits))
+	fmt.Println(infoStyle.Render("Current project: ") + budget.CurrentProject())
+	return nil
+}
+
+func runBudgetSet(cmd *cobra.Command, args []string) error {
+	settings, err := config.LoadSettings()
+	if err != nil {
+		return err
+	}
+	b := settings.Budget
+
+	scope := strings.ReplaceAll(strings.ToLower(args[0]), "_", "-")
+	var name string
+	switch scope {
+	case "daily", "per-request", "warn-at":
+		if len(args) != 2 {
+			return fmt.Errorf("usage: reign budget set %s <amount>", scope)
+		}
+	case "model", "project":
+		if len(args) != 3 {
+			return fmt.Errorf("usage: reign budget set %s <name> <amount>", scope)
+		}
+		name = args[1]
+	default:
+		return fmt.Errorf("unknown budget %q (use daily, per-request, model, project or warn-at)", args[0])
+	}
+
+	amount, err := parseBudgetAmount(args[len(args)-1])
+	if err != nil {
+		return err
+	}
+
+	switch scope {
+	case "daily":
+		b.Daily = amount
+	case "per-request":
+		b.PerRequest = amount
+	case "warn-at":
+		if amount > 1 {
+			return fmt.Errorf("warn-at is a share of the budget between 0 and 1, e.g. 0.8")
+		}
+		b.WarnAt = amount
+	case "model":
+		b.Models = setBudgetEntry(b.Models, name, amount)
+	case "project":
+		b.Projects = setBudgetEntry(b.Projects, name, amount)
+	}
+
+	if err := config.SaveSetting("budget", b); err != nil {
+		return err
+	}
+
+	label := scope + " budget"
+	if name != "" {
+		label = scope + " " + name + " budget"
+	}
+	if scope == "warn-at" {
+		label = "warn-at"
+	}
+	if amount == 0 {
+		fmt.Println(successStyle.Render(ui.WithIcon(ui.Icon.Check, "Removed "+label)))
+	} else {
+		fmt.Println(successStyle.Render(ui.WithIcon(ui.Icon.Check, fmt.Sprintf("Set %s to %s", label, strconv.FormatFloat(amount, 'f', -1, 64)))))
+	}
+	return nil
+}
+
+func parseBudgetAmount(s string) (float64, error) {
+	if strings.EqualFold(s, "off") {
+		return 0, nil
+	}
+	amount, err := strconv.ParseFloat(s, 64)
+	if err != nil || amount < 0 {
+		return 0, fmt.Errorf("invalid amount %q: use a number of credits or \"off\"", s)
+	}
+	return amount, nil
+}
+
+func setBudgetEntry(m map[string]float64, name string, amount float64) map[string]float64 {
+	if amount == 0 {
+		delete(m, name)
+		return m
+	}
+	if m == nil {
+		m = make(map[string]float64)
+	}
+	m[name] = amount
+	return m
+}
+
+// checkBudget stops a request that would exceed a budget unless forced, and
+// warns about budgets that are nearly used up
+func checkBudget(req client.ChatRequest, force bool) error {
+	warnings, err := evaluateBudget(req, nil)
+	for _, u := range warnings {
+		fmt.Fprintln(os.Stderr, infoStyle.Render(ui.WithIcon(ui.Icon.Warn,
+			fmt.Sprintf("%s budget %.0f%% used (%.4f of %.4f credits today)", u.Label(), u.Fraction()*100, u.Spent, u.Limit))))
+	}
+
+	var exceeded *budget.ExceededError
+	if errors.As(err, &exceeded) && force {
+		fmt.Fprintln(os.Stderr, errorStyle.Render(ui.WithIcon(ui.Icon.Warn, "Over budget, sending anyway (--force): "+err.Error())))
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("%w\nRaise the budget with 'reign budget set' or pass --force to send anyway", err)
+	}
+	return nil
+}
+
+// evaluateBudget checks a request against the configured budgets and today's
+// spending; see budget.Check. Without a known cost, the request is assumed to
+// cost what recent requests to the model did.
+func evaluateBudget(req client.ChatRequest, cost *float64) ([]budget.Usage, error) {
+	warnings, _, err := evaluateBudgetPending(req, cost, nil)
+	return warnings, err
+}
+
+// evaluateBudgetPending is evaluateBudget counting pending, requests already
+// allowed but not yet charged, as spent today. It also returns the estimate
+// the request was checked with, which is zero when no budgets are set.
+func evaluateBudgetPending(req client.ChatRequest, cost *float64, pending []budget.Spend) ([]budget.Usage, float64, error) {
+	settings, err := config.LoadSettings()
+	if err != nil {
+		return nil, 0, err
+	}
+	b := settings.Budget
+	if b.Daily == 0 && b.PerRequest == 0 && len(b.Models) == 0 && len(b.Projects) == 0 {
+		return nil, 0, nil
+	}
+
+	now := time.Now()
+	recent, err := budget.DefaultLedger().Since(now.Add(-estimateWindow))
+	if err != nil {
+		return nil, 0, err
+	}
+	var today []budget.Spend
+	for _, s := range recent {
+		if !s.Time.Before(budget.Today(now)) {
+			today = append(today, s)
+		}
+	}
+	today = append(today, pending...)
+
+	estimate := budget.Estimate(recent, req.Model)
+	if cost != nil {
+		estimate = *cost
+	}
+	warnings, err := budget.Check(b, today, req.Model, budget.CurrentProject(), estimate)
+	return warnings, estimate, err
+}
+
+// pendingSpend holds the estimated costs of requests sent concurrently, from
+// when they pass the budget check until their cost is recorded, so that
+// checks made meanwhile count them
+type pendingSpend struct {
+	mu     sync.Mutex
+	next   int
+	spends map[int]budget.Spend
+}
+
+// reserve checks a request against the budgets, counting pending requests,
+// and adds its estimate to them if it fits. Call release once the request's
+// cost has been recorded.
+func (p *pendingSpend) reserve(req client.ChatRequest) (release func(), err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	_, estimate, err := evaluateBudgetPending(req, nil, slices.Collect(maps.Values(p.spends)))
+	if err != nil {
+		return nil, err
+	}
+	if p.spends == nil {
+		p.spends = make(map[int]budget.Spend)
+	}
+	id := p.next
+	p.next++
+	p.spends[id] = budget.Spend{Time: time.Now().UTC(), Model: req.Model, Project: budget.CurrentProject(), Cost: estimate}
+	return func() {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		delete(p.spends, id)
+	}, nil
+}
+
+// recordSpend adds a request's cost to the budget ledger
+func recordSpend(req client.ChatRequest, resp *client.ChatResponse) {
+	if resp == nil {
+		return
+	}
+	recordCost(req.Model, resp.Cost)
+}
+
+// recordCost adds credits spent on a model to the budget ledger
+func recordCost(model string, cost float64) {
+	if cost <= 0 {
+		return
+	}
+	s := budget.Spend{Time: time.Now().UTC(), Model: model, Project: budget.CurrentProject(), Cost: cost}
+	if err := budget.DefaultLedger().Record(s); err != nil {
+		fmt.Fprintln(os.Stderr, infoStyle.Render("Spend not recorded for budgets: "+err.Error()))
+	}
+}
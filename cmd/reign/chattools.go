@@ -0,0 +1,144 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/sovereynai/reign/internal/client"
+	"github.com/sovereynai/reign/internal/tools"
+	"github.com/sovereynai/reign/internal/ui"
+)
+
+// maxToolRounds limits how many times a model may call tools before it must answer
+const maxToolRounds = 10
+
+// runChatWithTools sends the request with the declared tools, runs the calls
+// the model makes and sends their results back until it gives a final answer
+func runChatWithTools(c *client.ThroneClient, req client.ChatRequest, specs []tools.Spec, force, yes bool) error {
+	if !yes && !isTerminal(os.Stdin) {
+		for _, s := range specs {
+			if s.NeedsConfirm() {
+				return fmt.Errorf("tool %s needs confirmation but stdin is not a terminal; run with --yes to allow tool calls without asking", s.Name)
+			}
+		}
+	}
+	for _, s := range specs {
+		req.Tools = append(req.Tools, s.Definition())
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	fmt.Println(infoStyle.Render(ui.WithIcon(ui.Icon.Robot, "Submitting to throne daemon...")))
+	fmt.Println(infoStyle.Render(ui.WithIcon(ui.Icon.Memo, "Model: ")) + req.Model)
+	fmt.Println(infoStyle.Render(ui.WithIcon(ui.Icon.Speech, "Prompt: ")) + req.Messages[len(req.Messages)-1].Content)
+	fmt.Println()
+
+	stdin := bufio.NewReader(os.Stdin)
+	allowed := make(map[string]bool) // Tools the user approved for the rest of the chat
+	var totalLatency int64
+	for round := 0; ; round++ {
+		if round > 0 {
+			if err := checkBudget(req, force); err != nil {
+				return err
+			}
+		}
+
+		start := time.Now()
+		resp, err := c.SendChatContext(ctx, req)
+		recordRequest(req, resp, err, time.Since(start))
+		if err != nil {
+			return fmt.Errorf("inference failed: %w", err)
+		}
+		if !resp.Success {
+			return fmt.Errorf("inference returned success=false")
+		}
+		totalLatency += resp.LatencyMs
+
+		calls := resp.Message.ToolCalls
+		if len(calls) == 0 {
+			fmt.Println(titleStyle.Render(ui.WithIcon(ui.Icon.Sparkles, "Response")))
+			if resp.Message.Content != "" {
+				fmt.Println(resp.Message.Content)
+			} else {
+				fmt.Println(infoStyle.Render("(Inference completed but response was empty)"))
+			}
+			fmt.Println()
+			fmt.Println(infoStyle.Render(ui.WithIcon(ui.Icon.Speed,
+				fmt.Sprintf("Latency: %dms over %d request(s)", totalLatency, round+1))))
+			return nil
+		}
+		if round+1 >= maxToolRounds {
+			return fmt.Errorf("model was still calling tools after %d requests", maxToolRounds)
+		}
+
+		req.Messages = append(req.Messages, client.ChatMessage{
+			Role:      "assistant",
+			Content:   resp.Message.Content,
+			ToolCalls: calls,
+		})
+		for _, call := range calls {
+			result := runToolCall(ctx, stdin, specs, call, yes, allowed)
+			if ctx.Err() != nil {
+				return errors.New("chat interrupted")
+			}
+			req.Messages = append(req.Messages, client.ChatMessage{
+				Role:     "tool",
+				Content:  result,
+				ToolName: call.Function.Name,
+			})
+		}
+		fmt.Println()
+	}
+}
+
+// runToolCall asks for approval when needed, runs the call and returns the
+// text to give the model. Failures and refusals are reported to the model
+// rather than ending the chat, so it can try another way.
+func runToolCall(ctx context.Context, stdin *bufio.Reader, specs []tools.Spec, call client.ToolCall, yes bool, allowed map[string]bool) string {
+	name := call.Function.Name
+	args, _ := json.Marshal(call.Function.Arguments)
+	if call.Function.Arguments == nil {
+		args = []byte("{}")
+	}
+	fmt.Println(infoStyle.Render(ui.WithIcon(ui.Icon.Arrow, "Tool call: ")) + name + " " + infoStyle.Render(string(args)))
+
+	spec, ok := tools.Find(specs, name)
+	if !ok {
+		fmt.Println(errorStyle.Render(ui.WithIcon(ui.Icon.Cross, "Unknown tool")))
+		return fmt.Sprintf("Error: there is no tool named %q", name)
+	}
+
+	if spec.NeedsConfirm() && !yes && !allowed[name] {
+		fmt.Print(infoStyle.Render(fmt.Sprintf("Run %s? [y/N/a(lways)] ", name)))
+		answer, _ := stdin.ReadString('\n')
+		switch strings.ToLower(strings.TrimSpace(answer)) {
+		case "y", "yes":
+		case "a", "always":
+			allowed[name] = true
+		default:
+			fmt.Println(infoStyle.Render(ui.WithIcon(ui.Icon.Cross, "Declined")))
+			return "Error: the user declined to run this tool"
+		}
+	}
+
+	start := time.Now()
+	out, err := spec.Run(ctx, call.Function.Arguments)
+	elapsed := time.Since(start).Round(time.Millisecond)
+	if err != nil {
+		fmt.Println(errorStyle.Render(ui.WithIcon(ui.Icon.Cross, fmt.Sprintf("Failed after %s: %v", elapsed, err))))
+		if out != "" {
+			return fmt.Sprintf("Error: %v\nOutput:\n%s", err, out)
+		}
+		return fmt.Sprintf("Error: %v", err)
+	}
+	fmt.Println(successStyle.Render(ui.WithIcon(ui.Icon.Check, fmt.Sprintf("Done in %s (%d bytes)", elapsed, len(out)))))
+	return out
+}
@@ -2,6 +2,8 @@ package main
 
 import (
 	"fmt"
+	"math"
+	"time"
 
 	"github.com/sovereynai/reign/internal/client"
 	"github.com/sovereynai/reign/internal/ui"
@@ -20,7 +22,7 @@ func createDemoCommand() *cobra.Command {
 		Short: "Show AI Developer dashboard with mock data",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			stats := client.MockDeveloperStats()
-			fmt.Println(ui.RenderDeveloperDashboard(stats))
+			fmt.Println(ui.RenderDeveloperDashboard(stats, mockTrends()))
 			return nil
 		},
 	}
@@ -30,7 +32,7 @@ func createDemoCommand() *cobra.Command {
 		Short: "Show Node Operator dashboard with mock data",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			stats := client.MockOperatorStats()
-			fmt.Println(ui.RenderOperatorDashboard(stats))
+			fmt.Println(ui.RenderOperatorDashboard(stats, mockTrends()))
 			return nil
 		},
 	}
@@ -40,9 +42,9 @@ func createDemoCommand() *cobra.Command {
 		Short: "Show both dashboards with mock data",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			stats := client.MockBothStats()
-			fmt.Println(ui.RenderDeveloperDashboard(stats))
+			fmt.Println(ui.RenderDeveloperDashboard(stats, mockTrends()))
 			fmt.Println()
-			fmt.Println(ui.RenderOperatorDashboard(stats))
+			fmt.Println(ui.RenderOperatorDashboard(stats, mockTrends()))
 			return nil
 		},
 	}
@@ -50,3 +52,27 @@ func createDemoCommand() *cobra.Command {
 	demoCmd.AddCommand(demoDev, demoNode, demoBoth)
 	return demoCmd
 }
+
+// mockTrends generates sample chart history for the demo dashboards
+func mockTrends() *ui.Trends {
+	trends := &ui.Trends{
+		BurnRate:     []float64{14.2, 13.8, 15.1, 13.0, 12.7, 12.9, 12.3},
+		AvgLatencyMs: []float64{171, 165, 180, 162, 158, 149, 156},
+		QueueDepth:   []float64{8, 11, 15, 9, 7, 14, 12},
+	}
+	for i, v := range []float64{98.4, 112.0, 131.7, 127.9, 140.2, 154.6, 127.3} {
+		trends.EarningsPerDay = append(trends.EarningsPerDay, ui.Bucket{
+			Label: time.Now().AddDate(0, 0, i-6).Format("Mon"),
+			Value: v,
+		})
+	}
+	for h := 0; h < 24; h++ {
+		// Quiet nights, busy afternoons peaking around 2pm
+		v := 10 + 84*math.Exp(-math.Pow(float64(h-14), 2)/18)
+		trends.RequestsPerHour = append(trends.RequestsPerHour, ui.Bucket{
+			Label: fmt.Sprintf("%02d", h),
+			Value: math.Round(v),
+		})
+	}
+	return trends
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sovereynai/reign/internal/budget"
+	"github.com/sovereynai/reign/internal/client"
+	"github.com/sovereynai/reign/internal/estimate"
+	"github.com/sovereynai/reign/internal/history"
+	"github.com/sovereynai/reign/internal/ui"
+)
+
+// estimateHistory is how much request history estimates learn from
+const estimateHistory = 30 * 24 * time.Hour
+
+// estimateInput gathers what's known about a request's model. Anything
+// unavailable is left out and the estimate gets less precise.
+func estimateInput(c *client.ThroneClient, req client.ChatRequest) estimate.Input {
+	in := estimate.Input{Request: req}
+	if models, err := c.ListModels(); err == nil {
+		in.LocalModels = models
+	}
+	if locations, err := c.LocateModel(req.Model); err == nil {
+		in.Locations = locations
+	}
+	if stats, err := c.GetDashboardStats(); err == nil {
+		in.Stats = stats
+	}
+	in.History, _ = history.DefaultStore().Entries(history.Filter{Since: time.Now().Add(-estimateHistory)})
+	return in
+}
+
+// printEstimate shows a request's estimate and whether budgets would let it
+// through
+func printEstimate(e estimate.Estimate, req client.ChatRequest) {
+	fmt.Println(titleStyle.Render(ui.WithIcon(ui.Icon.Target, "Cost Estimate (dry run, nothing sent)")))
+	fmt.Println(infoStyle.Render("  Model:       ") + e.Model)
+	fmt.Println(infoStyle.Render("  Prompt:      ") + fmt.Sprintf("~%d tokens", e.PromptTokens))
+	fmt.Println(infoStyle.Render("  Output:      ") + fmt.Sprintf("~%.0f-%.0f tokens", e.OutputTokens.Low, e.OutputTokens.High))
+
+	switch e.Source {
+	case estimate.SourceLocal:
+		fmt.Println(infoStyle.Render("  Cost:        ") + successStyle.Render("free (runs locally)"))
+	case estimate.SourceUnknown:
+		fmt.Println(infoStyle.Render("  Cost:        ") + "unknown")
+	default:
+		fmt.Println(infoStyle.Render("  Cost:        ") + fmt.Sprintf("%.4f-%.4f credits (from %s)", e.Cost.Low, e.Cost.High, pricingSourceText(e.Source)))
+	}
+	if e.Source != estimate.SourceLocal {
+		fmt.Println(infoStyle.Render("  Nodes:       ") + fmt.Sprintf("%d hosting the model", e.Nodes))
+		fmt.Println(infoStyle.Render("  Queue wait:  ") + fmt.Sprintf("~%.1fs", e.QueueWaitSec))
+	}
+	for _, note := range e.Notes {
+		fmt.Println("  " + infoStyle.Render(ui.Icon.Arrow) + " " + note)
+	}
+
+	// Judge by the high end, as budgets should hold in the worst case
+	var cost *float64
+	if e.Source != estimate.SourceUnknown {
+		cost = &e.Cost.High
+	}
+	_, err := evaluateBudget(req, cost)
+	var exceeded *budget.ExceededError
+	switch {
+	case errors.As(err, &exceeded):
+		fmt.Println(errorStyle.Render(ui.WithIcon(ui.Icon.Warn, "Budget: would be blocked - "+err.Error())))
+	case err == nil:
+		fmt.Println(successStyle.Render(ui.WithIcon(ui.Icon.Check, "Budget: within limits")))
+	}
+}
+
+func pricingSourceText(source string) string {
+	switch source {
+	case estimate.SourceNodePricing:
+		return "node pricing"
+	case estimate.SourceHistory:
+		return "your past requests"
+	case estimate.SourceDashboard:
+		return "today's average"
+	}
+	return source
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/sovereynai/reign/internal/client"
+	"github.com/sovereynai/reign/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+func createEventsCommand() *cobra.Command {
+	eventsCmd := &cobra.Command{
+		Use:   "events",
+		Short: "Tail job, peer, model and alert events from the network",
+		Long: `Stream events pushed by throne as they happen: job state changes, peers
+joining or leaving, models becoming available and alerts. Dropped connections
+are retried automatically and resume where they left off.
+
+Example:
+  reign events                           # Everything
+  reign events -t job -t alert           # Only jobs and alerts
+  reign events --model llama3.2          # Events about one model
+  reign events --node abc123 --json      # NDJSON for one node
+  reign events --resume 4182             # Replay events after ID 4182
+`,
+		RunE: runEvents,
+	}
+	eventsCmd.Flags().StringSliceP("type", "t", nil, "Event types to show: "+strings.Join(client.EventTypes, ", "))
+	eventsCmd.Flags().String("model", "", "Only events about models matching this name")
+	eventsCmd.Flags().String("node", "", "Only events about nodes matching this ID")
+	eventsCmd.Flags().String("resume", "", "Resume after this event ID")
+	eventsCmd.Flags().Bool("json", false, "Print events as NDJSON")
+	return eventsCmd
+}
+
+func runEvents(cmd *cobra.Command, args []string) error {
+	types, _ := cmd.Flags().GetStringSlice("type")
+	model, _ := cmd.Flags().GetString("model")
+	node, _ := cmd.Flags().GetString("node")
+	resume, _ := cmd.Flags().GetString("resume")
+	asJSON, _ := cmd.Flags().GetBool("json")
+
+	for _, t := range types {
+		if !slices.Contains(client.EventTypes, t) {
+			return fmt.Errorf("unknown event type %q (valid: %s)", t, strings.Join(client.EventTypes, ", "))
+		}
+	}
+
+	c, err := getThroneClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if !asJSON {
+		fmt.Fprintln(os.Stderr, infoStyle.Render(fmt.Sprintf("Streaming events from %s (Ctrl+C to stop)", c.BaseURL)))
+	}
+
+	opts := client.SubscribeOptions{
+		Types:       types,
+		LastEventID: resume,
+		OnError: func(err error, retryIn time.Duration) {
+			fmt.Fprintln(os.Stderr, errorStyle.Render(ui.WithIcon(ui.Icon.Fail, fmt.Sprintf("%v - reconnecting in %s", err, retryIn))))
+		},
+	}
+
+	err = c.Subscribe(ctx, opts, func(e client.Event) error {
+		if !eventMatches(e, model, node) {
+			return nil
+		}
+		if asJSON {
+			line, err := json.Marshal(e)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(line))
+			return nil
+		}
+		fmt.Println(formatEvent(e))
+		return nil
+	})
+	if ctx.Err() != nil {
+		// Interrupted
+		return nil
+	}
+	return err
+}
+
+// eventMatches applies the --model and --node filters to an event payload
+func eventMatches(e client.Event, model, node string) bool {
+	if model == "" && node == "" {
+		return true
+	}
+	var subject struct {
+		Model  string `json:"model"`
+		NodeID string `json:"node_id"`
+	}
+	if err := e.Decode(&subject); err != nil {
+		return false
+	}
+	contains := func(s, sub string) bool {
+		return strings.Contains(strings.ToLower(s), strings.ToLower(sub))
+	}
+	return (model == "" || contains(subject.Model, model)) &&
+		(node == "" || contains(subject.NodeID, node))
+}
+
+// formatEvent renders an event as one line for the terminal
+func formatEvent(e client.Event) string {
+	prefix := infoStyle.Render(e.Time.Local().Format("15:04:05")) + "  " + fmt.Sprintf("%-6s", e.Type) + "  "
+
+	switch e.Type {
+	case client.EventJob:
+		var job client.JobEvent
+		if err := e.Decode(&job); err == nil {
+			transition := job.Status
+			if job.PreviousStatus != "" {
+				transition = job.PreviousStatus + " " + ui.Icon.Arrow + " " + job.Status
+			}
+			line := fmt.Sprintf("%s  %s  %s", ui.ShortID(job.ID), job.Model, transition)
+			if job.NodeID != "" {
+				line += "  node=" + job.NodeID
+			}
+			if job.Error != "" {
+				line += "  " + errorStyle.Render(job.Error)
+			}
+			return prefix + line
+		}
+	case client.EventPeer:
+		var peer client.PeerEvent
+		if err := e.Decode(&peer); err == nil {
+			line := fmt.Sprintf("%s %s", peer.NodeID, peer.Action)
+			if peer.Address != "" {
+				line += fmt.Sprintf(" (%s)", peer.Address)
+			}
+			return prefix + line
+		}
+	case client.EventModel:
+		var m client.ModelEvent
+		if err := e.Decode(&m); err == nil {
+			if m.Available {
+				return prefix + fmt.Sprintf("%s available on %s", m.Model, m.NodeID)
+			}
+			return prefix + fmt.Sprintf("%s no longer available on %s", m.Model, m.NodeID)
+		}
+	case client.EventAlert:
+		var alert client.AlertEvent
+		if err := e.Decode(&alert); err == nil {
+			line := fmt.Sprintf("[%s] %s", alert.Level, alert.Message)
+			if alert.NodeID != "" {
+				line += fmt.Sprintf(" (node %s)", alert.NodeID)
+			}
+			if alert.Level == "error" || alert.Level == "warning" {
+				line = errorStyle.Render(line)
+			}
+			return prefix + line
+		}
+	}
+
+	// Unknown type or payload: show the raw data
+	return prefix + string(e.Data)
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/sovereynai/reign/internal/exporter"
+	"github.com/sovereynai/reign/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+func createExporterCommand() *cobra.Command {
+	exporterCmd := &cobra.Command{
+		Use:   "exporter",
+		Short: "Serve dashboard stats as Prometheus metrics",
+		Long: `Poll throne for dashboard stats and serve them on /metrics in the Prometheus
+text format, so existing Grafana boards and alerting can cover Sovereyn nodes.
+
+Metrics are prefixed with sovereyn_ and labelled by model, resource, alert
+level, earnings period and revenue source where relevant.
+
+Example:
+  reign exporter                              # Listen on :9109
+  reign exporter --listen 127.0.0.1:9109 --interval 30s
+
+Prometheus scrape config:
+  - job_name: sovereyn
+    static_configs:
+      - targets: ["localhost:9109"]
+`,
+		RunE: runExporter,
+	}
+	exporterCmd.Flags().String("listen", ":9109", "Address to serve metrics on")
+	exporterCmd.Flags().Duration("interval", 15*time.Second, "How often to fetch stats from throne")
+	return exporterCmd
+}
+
+func runExporter(cmd *cobra.Command, args []string) error {
+	listen, _ := cmd.Flags().GetString("listen")
+	interval, _ := cmd.Flags().GetDuration("interval")
+	if interval < time.Second {
+		return fmt.Errorf("interval must be at least 1s")
+	}
+
+	c, err := getThroneClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	exp := exporter.New(c, interval)
+	go exp.Run(ctx)
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", exp)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintln(w, `<html><body><h1>Sovereyn exporter</h1><p><a href="/metrics">Metrics</a></p></body></html>`)
+	})
+
+	server := &http.Server{Addr: listen, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	fmt.Println(successStyle.Render(ui.WithIcon(ui.Icon.Chart, fmt.Sprintf("Serving metrics on http://%s/metrics", displayAddr(listen)))))
+	fmt.Println(infoStyle.Render(fmt.Sprintf("Fetching stats from %s every %s (Ctrl+C to stop)", c.BaseURL, interval)))
+
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to serve metrics: %w", err)
+	}
+	return nil
+}
+
+// displayAddr turns ":9109" into "localhost:9109" for printing
+func displayAddr(addr string) string {
+	if len(addr) > 0 && addr[0] == ':' {
+		return "localhost" + addr
+	}
+	return addr
+}
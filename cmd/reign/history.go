@@ -0,0 +1,548 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/sovereynai/reign/internal/client"
+	"github.com/sovereynai/reign/internal/config"
+	"github.com/sovereynai/reign/internal/history"
+	"github.com/sovereynai/reign/internal/stats"
+	"github.com/sovereynai/reign/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+func createDevHistoryCommand() *cobra.Command {
+	historyCmd := &cobra.Command{
+		Use:   "history",
+		Short: "View and search your request history",
+		Long: `Every chat request made with reign is recorded locally
+(~/.sovereyn/history) with its model, prompt, response, node, latency and cost.
+
+To limit what is kept, set "history" in ~/.sovereyn/reign.json:
+  {"history": {"content": "truncate", "max_chars": 200}}   # Shorten prompts and responses
+  {"history": {"content": "none"}}                         # Keep only metadata
+  {"history": {"disabled": true}}                          # Don't record anything
+
+Example:
+  reign dev history list --model llama --since 7d
+  reign dev history search "error handling"
+  reign dev history show 3f9c2a
+  reign dev history replay 3f9c2a -m qwen2.5:7b
+  reign dev history rm --before 30d
+`,
+		RunE: runHistoryList,
+	}
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List recent requests",
+		Args:  cobra.NoArgs,
+		RunE:  runHistoryList,
+	}
+
+	showCmd := &cobra.Command{
+		Use:   "show <id>",
+		Short: "Show a request and its response",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runHistoryShow,
+	}
+	showCmd.Flags().Bool("json", false, "Output the entry as JSON")
+
+	searchCmd := &cobra.Command{
+		Use:   "search <text>",
+		Short: "Find requests whose prompt or response contains text",
+		Args:  cobra.MinimumNArgs(1),
+		RunE:  runHistorySearch,
+	}
+
+	rmCmd := &cobra.Command{
+		Use:   "rm [id...]",
+		Short: "Delete requests from history",
+		RunE:  runHistoryRm,
+	}
+	rmCmd.Flags().String("before", "", "Delete requests older than a window (e.g. 30d) or date (YYYY-MM-DD)")
+	rmCmd.Flags().Bool("all", false, "Delete the whole history")
+
+	for _, cmd := range []*cobra.Command{historyCmd, listCmd, searchCmd} {
+		cmd.Flags().StringP("model", "m", "", "Only requests to models containing this name")
+		cmd.Flags().String("since", "", "Only requests after a window (e.g. 24h, 7d) or date (YYYY-MM-DD)")
+		cmd.Flags().String("until", "", "Only requests before a window (e.g. 24h, 7d) or date (YYYY-MM-DD)")
+		cmd.Flags().IntP("limit", "n", 20, "Maximum number of requests to show (0 for all)")
+		cmd.Flags().Bool("json", false, "Output as JSON")
+	}
+
+	replayCmd := &cobra.Command{
+		Use:   "replay <id>",
+		Short: "Re-run a request and compare with the original",
+		Long: `Send a recorded request again with the same messages and parameters,
+optionally to another model, and compare the outputs, latency and cost with
+the original side by side. The replay is recorded in history too.`,
+		Args: cobra.ExactArgs(1),
+		RunE: runHistoryReplay,
+	}
+	replayCmd.Flags().StringP("model", "m", "", "Model to replay on (default: the original model)")
+	replayCmd.Flags().Int("width", 100, "Width of the side-by-side comparison")
+	replayCmd.Flags().Bool("force", false, "Send even if it would exceed a budget")
+
+	historyCmd.AddCommand(listCmd, showCmd, searchCmd, replayCmd, rmCmd)
+	return historyCmd
+}
+
+func runHistoryList(cmd *cobra.Command, args []string) error {
+	filter, err := historyFilter(cmd)
+	if err != nil {
+		return err
+	}
+	asJSON, _ := cmd.Flags().GetBool("json")
+
+	summaries, err := history.DefaultStore().List(filter)
+	if err != nil {
+		return err
+	}
+
+	if asJSON {
+		if summaries == nil {
+			summaries = []history.Summary{}
+		}
+		return writeJSON(summaries)
+	}
+
+	fmt.Println(titleStyle.Render(ui.WithIcon(ui.Icon.Memo, "Request History")))
+	if len(summaries) == 0 {
+		fmt.Println(infoStyle.Render("No requests recorded yet. Try: reign chat \"Hello\""))
+		return nil
+	}
+	for _, s := range summaries {
+		printHistoryLine(s.ID, s.Time, s.Model, s.LatencyMs, s.Cost, s.Failed, s.Preview)
+	}
+	return nil
+}
+
+func runHistoryShow(cmd *cobra.Command, args []string) error {
+	asJSON, _ := cmd.Flags().GetBool("json")
+
+	e, err := history.DefaultStore().Get(args[0])
+	if err != nil {
+		return err
+	}
+	if asJSON {
+		return writeJSON(e)
+	}
+
+	fmt.Println(titleStyle.Render(ui.WithIcon(ui.Icon.Memo, "Request "+e.ID)))
+	fmt.Println(infoStyle.Render("Time:     ") + e.Time.Local().Format("2006-01-02 15:04:05"))
+	fmt.Println(infoStyle.Render("Kind:     ") + e.Kind)
+	fmt.Println(infoStyle.Render("Model:    ") + e.Model)
+	if e.NodeID != "" {
+		fmt.Println(infoStyle.Render("Node:     ") + e.NodeID)
+	}
+	fmt.Println(infoStyle.Render("Latency:  ") + fmt.Sprintf("%dms", e.LatencyMs))
+	if e.Cost > 0 {
+		fmt.Println(infoStyle.Render("Cost:     ") + fmt.Sprintf("%.4f credits", e.Cost))
+	}
+	for _, k := range slices.Sorted(maps.Keys(e.Params)) {
+		fmt.Println(infoStyle.Render(fmt.Sprintf("%-10s", k+":")) + fmt.Sprint(e.Params[k]))
+	}
+	if e.Content != "" {
+		fmt.Println(infoStyle.Render("Content:  ") + e.Content + " by privacy settings")
+	}
+
+	for _, m := range e.Messages {
+		fmt.Println()
+		fmt.Println(successStyle.Render(ui.WithIcon(ui.Icon.Speech, roleTitle(m.Role))))
+		fmt.Println(m.Content)
+	}
+	fmt.Println()
+	if e.Error != "" {
+		fmt.Println(errorStyle.Render(ui.WithIcon(ui.Icon.Fail, "Error: ")) + e.Error)
+		return nil
+	}
+	fmt.Println(successStyle.Render(ui.WithIcon(ui.Icon.Sparkles, "Response")))
+	fmt.Println(e.Response)
+	return nil
+}
+
+func runHistorySearch(cmd *cobra.Command, args []string) error {
+	filter, err := historyFilter(cmd)
+	if err != nil {
+		return err
+	}
+	asJSON, _ := cmd.Flags().GetBool("json")
+	query := strings.Join(args, " ")
+
+	entries, err := history.DefaultStore().Search(query, filter)
+	if err != nil {
+		return err
+	}
+
+	if asJSON {
+		if entries == nil {
+			entries = []history.Entry{}
+		}
+		return writeJSON(entries)
+	}
+
+	fmt.Println(titleStyle.Render(ui.WithIcon(ui.Icon.Microscope, fmt.Sprintf("Requests matching %q", query))))
+	if len(entries) == 0 {
+		fmt.Println(infoStyle.Render("No matching requests."))
+		return nil
+	}
+	for _, e := range entries {
+		printHistoryLine(e.ID, e.Time, e.Model, e.LatencyMs, e.Cost, e.Error != "", matchContext(&e, query))
+	}
+	return nil
+}
+
+func runHistoryRm(cmd *cobra.Command, args []string) error {
+	before, _ := cmd.Flags().GetString("before")
+	all, _ := cmd.Flags().GetBool("all")
+
+	store := history.DefaultStore()
+	var removed int
+	var err error
+	switch {
+	case all:
+		removed, err = store.Remove(func(history.Summary) bool { return true })
+	case before != "":
+		var cutoff time.Time
+		cutoff, err = parseHistoryTime(before)
+		if err != nil {
+			return err
+		}
+		removed, err = store.Remove(func(s history.Summary) bool { return s.Time.Before(cutoff) })
+	case len(args) > 0:
+		removed, err = store.RemoveIDs(args)
+	default:
+		return fmt.Errorf("specify request IDs, --before or --all")
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(successStyle.Render(ui.Icon.Check + fmt.Sprintf(" Removed %d request(s) from history", removed)))
+	return nil
+}
+
+func runHistoryReplay(cmd *cobra.Command, args []string) error {
+	model, _ := cmd.Flags().GetString("model")
+	width, _ := cmd.Flags().GetInt("width")
+	force, _ := cmd.Flags().GetBool("force")
+
+	original, err := history.DefaultStore().Get(args[0])
+	if err != nil {
+		return err
+	}
+	req, err := replayRequest(original, model)
+	if err != nil {
+		return err
+	}
+	if err := checkBudget(req, force); err != nil {
+		return err
+	}
+
+	c, err := getThroneClient()
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(infoStyle.Render(ui.WithIcon(ui.Icon.Refresh, fmt.Sprintf("Replaying %s on %s...", original.ID, req.Model))))
+	if original.Content == "truncated" {
+		fmt.Println(infoStyle.Render(ui.WithIcon(ui.Icon.Alert, "The recorded prompt was truncated by privacy settings, so results may differ")))
+	}
+
+	start := time.Now()
+	resp, reqErr := c.SendChat(req)
+	elapsed := time.Since(start)
+	recordRequest(req, resp, reqErr, elapsed)
+
+	replay := ui.Comparison{Title: "Replay", Model: req.Model, LatencyMs: elapsed.Milliseconds()}
+	switch {
+	case reqErr != nil:
+		replay.Error = reqErr.Error()
+	case !resp.Success:
+		replay.Error = "inference returned success=false"
+	}
+	if resp != nil {
+		replay.Output = resp.Message.Content
+		replay.Cost = resp.Cost
+		if resp.LatencyMs > 0 {
+			replay.LatencyMs = resp.LatencyMs
+		}
+	}
+
+	fmt.Println()
+	fmt.Print(ui.RenderComparison(ui.Comparison{
+		Title:     "Original " + original.Time.Local().Format("Jan 2 15:04"),
+		Model:     original.Model,
+		Output:    original.Response,
+		Error:     original.Error,
+		LatencyMs: original.LatencyMs,
+		Cost:      original.Cost,
+	}, replay, width))
+	return nil
+}
+
+// replayRequest rebuilds the request behind a history entry, optionally
+// for another model
+func replayRequest(e *history.Entry, model string) (client.ChatRequest, error) {
+	var req client.ChatRequest
+	if e.Kind != history.KindChat {
+		return req, fmt.Errorf("replaying %s requests is not supported", e.Kind)
+	}
+	if e.Content == "omitted" {
+		return req, fmt.Errorf("request %s can't be replayed: its content was not stored (history content setting is \"none\")", e.ID)
+	}
+
+	// Params hold the request's other fields as they were sent
+	fields := make(map[string]any, len(e.Params)+2)
+	maps.Copy(fields, e.Params)
+	fields["model"] = e.Model
+	fields["messages"] = e.Messages
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return req, fmt.Errorf("failed to rebuild request: %w", err)
+	}
+	if err := json.Unmarshal(data, &req); err != nil {
+		return req, fmt.Errorf("failed to rebuild request: %w", err)
+	}
+	if model != "" {
+		req.Model = model
+	}
+	return req, nil
+}
+
+// historyFilter builds a filter from the --model, --since, --until and
+// --limit flags
+func historyFilter(cmd *cobra.Command) (history.Filter, error) {
+	var f history.Filter
+	f.Model, _ = cmd.Flags().GetString("model")
+	f.Limit, _ = cmd.Flags().GetInt("limit")
+
+	var err error
+	if since, _ := cmd.Flags().GetString("since"); since != "" {
+		if f.Since, err = parseHistoryTime(since); err != nil {
+			return f, err
+		}
+	}
+	if until, _ := cmd.Flags().GetString("until"); until != "" {
+		if f.Until, err = parseHistoryTime(until); err != nil {
+			return f, err
+		}
+	}
+	return f, nil
+}
+
+// parseHistoryTime accepts a date (YYYY-MM-DD, local time) or a look-back
+// window such as 24h or 7d
+func parseHistoryTime(s string) (time.Time, error) {
+	if t, err := time.ParseInLocation("2006-01-02", s, time.Local); err == nil {
+		return t, nil
+	}
+	window, err := stats.ParseWindow(s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q (use a date like 2024-05-01 or a window like 7d)", s)
+	}
+	return time.Now().Add(-window), nil
+}
+
+func printHistoryLine(id string, t time.Time, model string, latencyMs int64, cost float64, failed bool, text string) {
+	status := successStyle.Render(ui.Icon.Check)
+	if failed {
+		status = errorStyle.Render(ui.Icon.Cross)
+	}
+	costText := ""
+	if cost > 0 {
+		costText = fmt.Sprintf("%.4f cr", cost)
+	}
+	fmt.Printf("  %s %s  %s  %-20s %7s %10s  %s\n",
+		status,
+		successStyle.Render(id),
+		infoStyle.Render(t.Local().Format("2006-01-02 15:04")),
+		model,
+		fmt.Sprintf("%dms", latencyMs),
+		costText,
+		text)
+}
+
+// matchContext returns the text around the first match of query in an entry
+func matchContext(e *history.Entry, query string) string {
+	texts := []string{e.Prompt(), e.Response}
+	for _, m := range e.Messages {
+		texts = append(texts, m.Content)
+	}
+	for _, text := range texts {
+		text = strings.Join(strings.Fields(text), " ")
+		i := strings.Index(strings.ToLower(text), strings.ToLower(query))
+		if i < 0 {
+			continue
+		}
+		start, end := max(0, i-30), min(len(text), i+len(query)+30)
+		snippet := strings.ToValidUTF8(text[start:end], "")
+		if start > 0 {
+			snippet = "..." + snippet
+		}
+		if end < len(text) {
+			snippet += "..."
+		}
+		return snippet
+	}
+	return ""
+}
+
+// recordRequest saves a chat request and its outcome to the local history,
+// honoring the user's history privacy settings, and charges its cost to the
+// budgets
+func recordRequest(req client.ChatRequest, resp *client.ChatResponse, reqErr error, elapsed time.Duration) {
+	recordSpend(req, resp)
+
+	settings, err := config.LoadSettings()
+	if err != nil || settings.History.Disabled {
+		return
+	}
+
+	e := &history.Entry{
+		Kind:      history.KindChat,
+		Model:     req.Model,
+		Messages:  append([]client.ChatMessage(nil), req.Messages...),
+		Params:    requestParams(req),
+		LatencyMs: elapsed.Milliseconds(),
+	}
+	switch {
+	case reqErr != nil:
+		e.Error = reqErr.Error()
+	case !resp.Success:
+		e.Error = "inference returned success=false"
+	}
+	if resp != nil {
+		e.Response = resp.Message.Content
+		e.NodeID = resp.NodeID
+		e.Cost = resp.Cost
+		if resp.LatencyMs > 0 {
+			e.LatencyMs = resp.LatencyMs
+		}
+	}
+
+	saveHistory(e, settings.History)
+}
+
+// recordEmbedRequest saves an embedding request to the local history, with
+// its inputs as user messages, and charges its cost to the budgets
+func recordEmbedRequest(req client.EmbedRequest, resp *client.EmbedResponse, reqErr error, elapsed time.Duration) {
+	if resp != nil {
+		recordCost(req.Model, resp.Cost)
+	}
+
+	settings, err := config.LoadSettings()
+	if err != nil || settings.History.Disabled {
+		return
+	}
+
+	e := &history.Entry{
+		Kind:      history.KindEmbedding,
+		Model:     req.Model,
+		LatencyMs: elapsed.Milliseconds(),
+	}
+	for _, input := range req.Input {
+		e.Messages = append(e.Messages, client.ChatMessage{Role: "user", Content: input})
+	}
+	if reqErr != nil {
+		e.Error = reqErr.Error()
+	}
+	if resp != nil {
+		dims := 0
+		if len(resp.Embeddings) > 0 {
+			dims = len(resp.Embeddings[0])
+		}
+		e.Response = fmt.Sprintf("%d embedding(s) of %d dimensions", len(resp.Embeddings), dims)
+		e.NodeID = resp.NodeID
+		e.Cost = resp.Cost
+	}
+
+	saveHistory(e, settings.History)
+}
+
+// recordVisionRequest saves a vision request to the local history, with the
+// top predictions as its response, and charges its cost to the budgets. The
+// image itself isn't kept.
+func recordVisionRequest(req client.VisionRequest, resp *client.VisionResponse, reqErr error, elapsed time.Duration) {
+	if resp != nil {
+		recordCost(req.Model, resp.Cost)
+	}
+
+	settings, err := config.LoadSettings()
+	if err != nil || settings.History.Disabled {
+		return
+	}
+
+	e := &history.Entry{
+		Kind:      history.KindVision,
+		Model:     req.Model,
+		Params:    map[string]any{"top_k": req.TopK},
+		LatencyMs: elapsed.Milliseconds(),
+	}
+	if reqErr != nil {
+		e.Error = reqErr.Error()
+	}
+	if resp != nil {
+		var labels []string
+		for _, p := range resp.Predictions {
+			labels = append(labels, fmt.Sprintf("%s (%.2f)", p.Label, p.Score))
+		}
+		e.Response = strings.Join(labels, ", ")
+		e.NodeID = resp.NodeID
+		e.Cost = resp.Cost
+		if resp.LatencyMs > 0 {
+			e.LatencyMs = resp.LatencyMs
+		}
+	}
+
+	saveHistory(e, settings.History)
+}
+
+// saveHistory applies the privacy settings to an entry and adds it to the
+// history
+func saveHistory(e *history.Entry, settings config.HistorySettings) {
+	if err := history.ApplyPrivacy(e, settings); err != nil {
+		fmt.Fprintln(os.Stderr, infoStyle.Render("Request not saved to history: "+err.Error()))
+		return
+	}
+	if err := history.DefaultStore().Add(e); err != nil {
+		fmt.Fprintln(os.Stderr, infoStyle.Render("Could not save request to history: "+err.Error()))
+	}
+}
+
+// requestParams returns the fields of a request other than the model and
+// messages, so history keeps whatever options the request was sent with
+func requestParams(req client.ChatRequest) map[string]any {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return nil
+	}
+	var params map[string]any
+	if err := json.Unmarshal(data, &params); err != nil {
+		return nil
+	}
+	delete(params, "model")
+	delete(params, "messages")
+	delete(params, "stream") // Replays don't stream
+	return params
+}
+
+func roleTitle(role string) string {
+	if role == "" {
+		return "Message"
+	}
+	return strings.ToUpper(role[:1]) + role[1:]
+}
+
+func writeJSON(v any) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
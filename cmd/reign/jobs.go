@@ -1,6 +1,15 @@
 package main
 
 import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/sovereynai/reign/internal/client"
 	"github.com/sovereynai/reign/internal/ui"
 	"github.com/spf13/cobra"
 )
@@ -13,24 +22,209 @@ Shows running, queued, completed, and failed jobs with progress bars and timing
 
 This is particularly useful for node operators to monitor their workload in real-time.
 
+Keys:
+  up/down, j/k   Select a job
+  enter          Show job details (full ID, prompt, cost, timeline, error)
+  /              Filter by text, or model:NAME node:ID status:STATUS
+  f              Cycle status filter
+  s              Cycle sort order (default, newest, longest, model)
+  c              Cancel the selected job (asks for confirmation)
+  r              Refresh now
+
 Example:
-  reign jobs           # Start live jobs monitor
+  reign jobs           # Show current jobs, press r to refresh
+  reign jobs -w        # Poll for updates every second
+  reign jobs -w -n 5   # Poll every 5 seconds
   reign node jobs      # Same thing (alias)
+  reign jobs --plain -w          # Log job state changes as text lines
+  reign jobs --json -w | jq .    # Stream state changes as NDJSON events
+
+Without a terminal (for example when piped) plain output is used automatically.
 `,
-	Run: func(cmd *cobra.Command, args []string) {
-		if err := ui.ShowLiveJobs(); err != nil {
-			// If Bubble Tea fails (no TTY), show a message
-			println("❌ Live jobs viewer requires a terminal (TTY)")
-			println("💡 Tip: Use 'reign node status' for a snapshot view")
-		}
-	},
+	RunE: runJobs,
 }
 
 func init() {
-	jobsCmd.Flags().BoolP("watch", "w", false, "Watch mode (continuous updates)")
-	jobsCmd.Flags().IntP("refresh", "n", 1, "Refresh interval in seconds")
+	addJobsFlags(jobsCmd)
+}
+
+func addJobsFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolP("watch", "w", false, "Watch mode (continuous updates)")
+	cmd.Flags().IntP("refresh", "n", 1, "Refresh interval in seconds")
+	cmd.Flags().Bool("plain", false, "Print job state changes as log lines instead of the interactive viewer")
+	cmd.Flags().Bool("json", false, "Print job state changes as NDJSON events (implies --plain)")
+}
+
+func runJobs(cmd *cobra.Command, args []string) error {
+	watch, _ := cmd.Flags().GetBool("watch")
+	refresh, _ := cmd.Flags().GetInt("refresh")
+	if refresh < 1 {
+		return fmt.Errorf("refresh interval must be at least 1 second")
+	}
+
+	plain, _ := cmd.Flags().GetBool("plain")
+	asJSON, _ := cmd.Flags().GetBool("json")
+	if plain || asJSON || !isTerminal(os.Stdout) {
+		interval := time.Duration(0)
+		if watch {
+			interval = time.Duration(refresh) * time.Second
+		}
+		return streamJobEvents(os.Stdout, interval, asJSON)
+	}
+
+	opts := ui.LiveJobsOptions{
+		Watch:   watch,
+		Refresh: time.Duration(refresh) * time.Second,
+	}
+	if err := ui.ShowLiveJobs(opts); err != nil {
+		// If Bubble Tea fails (no TTY), show a message
+		fmt.Println(errorStyle.Render(ui.WithIcon(ui.Icon.Fail, "Live jobs viewer requires a terminal (TTY)")))
+		fmt.Println(infoStyle.Render(ui.WithIcon(ui.Icon.Tip, "Tip: Use 'reign node status' for a snapshot view")))
+	}
+	return nil
 }
 
 func RegisterJobsCommand(rootCmd *cobra.Command) {
 	rootCmd.AddCommand(jobsCmd)
 }
+
+// isTerminal reports whether f is an interactive terminal
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// jobEvent is one line of headless jobs output
+type jobEvent struct {
+	Time           time.Time `json:"time"`
+	Event          string    `json:"event"` // "status", "fetch_error" or "connected"
+	JobID          string    `json:"job_id,omitempty"`
+	Model          string    `json:"model,omitempty"`
+	NodeID         string    `json:"node_id,omitempty"`
+	Status         string    `json:"status,omitempty"`
+	PreviousStatus string    `json:"previous_status,omitempty"`
+	DurationMs     int64     `json:"duration_ms,omitempty"`
+	Tokens         int       `json:"tokens_generated,omitempty"`
+	Cost           float64   `json:"cost,omitempty"`
+	Error          string    `json:"error,omitempty"`
+}
+
+// streamJobEvents prints a "status" event for every job that is new or has
+// changed state. With a zero interval it reports the current jobs once;
+// otherwise it polls until interrupted.
+func streamJobEvents(w io.Writer, interval time.Duration, asJSON bool) error {
+	emit := func(e jobEvent) {
+		if asJSON {
+			line, _ := json.Marshal(e)
+			fmt.Fprintln(w, string(line))
+			return
+		}
+		fmt.Fprintln(w, formatJobEvent(e))
+	}
+
+	var c *client.ThroneClient
+	seen := map[string]string{} // Job ID -> last status
+	healthy := true
+
+	poll := func() error {
+		var err error
+		if c == nil {
+			c, err = getThroneClient()
+		}
+		var jobs *client.LiveJobsResponse
+		if err == nil {
+			jobs, err = c.GetLiveJobs()
+		}
+		if err != nil {
+			// Rediscover throne next time in case it moved
+			c = nil
+			return err
+		}
+		if !healthy {
+			healthy = true
+			emit(jobEvent{Time: time.Now().UTC(), Event: "connected"})
+		}
+
+		current := map[string]string{}
+		for _, job := range append(jobs.Active, jobs.Recent...) {
+			current[job.ID] = job.Status
+			previous, known := seen[job.ID]
+			if known && previous == job.Status {
+				continue
+			}
+			emit(jobEvent{
+				Time:           time.Now().UTC(),
+				Event:          "status",
+				JobID:          job.ID,
+				Model:          job.Model,
+				NodeID:         job.NodeID,
+				Status:         job.Status,
+				PreviousStatus: previous,
+				DurationMs:     job.Duration,
+				Tokens:         job.TokensGenerated,
+				Cost:           job.Cost,
+				Error:          job.Error,
+			})
+		}
+		// Forget jobs throne no longer reports
+		seen = current
+		return nil
+	}
+
+	if interval <= 0 {
+		return poll()
+	}
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		// Errors are reported as events; keep polling while throne restarts
+		if err := poll(); err != nil {
+			healthy = false
+			emit(jobEvent{Time: time.Now().UTC(), Event: "fetch_error", Error: err.Error()})
+		}
+		select {
+		case <-interrupt:
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// formatJobEvent renders an event as a single log line
+func formatJobEvent(e jobEvent) string {
+	ts := e.Time.Format(time.RFC3339)
+	switch e.Event {
+	case "fetch_error":
+		return fmt.Sprintf("%s  error  %s", ts, e.Error)
+	case "connected":
+		return fmt.Sprintf("%s  connected to throne", ts)
+	}
+
+	transition := e.Status
+	if e.PreviousStatus != "" {
+		transition = e.PreviousStatus + " -> " + e.Status
+	}
+	fields := []string{ts, e.JobID, e.Model, transition}
+	if e.NodeID != "" {
+		fields = append(fields, "node="+e.NodeID)
+	}
+	if e.Status == "completed" || e.Status == "failed" {
+		if e.DurationMs > 0 {
+			fields = append(fields, fmt.Sprintf("duration=%s", time.Duration(e.DurationMs)*time.Millisecond))
+		}
+		if e.Tokens > 0 {
+			fields = append(fields, fmt.Sprintf("tokens=%d", e.Tokens))
+		}
+		if e.Cost > 0 {
+			fields = append(fields, fmt.Sprintf("cost=%.2f", e.Cost))
+		}
+	}
+	if e.Error != "" {
+		fields = append(fields, fmt.Sprintf("error=%q", e.Error))
+	}
+	return strings.Join(fields, "  ")
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/sovereynai/reign/internal/client"
+	"github.com/sovereynai/reign/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+func createDevLimitsCommand() *cobra.Command {
+	limitsCmd := &cobra.Command{
+		Use:   "limits",
+		Short: "Check rate limits & quotas",
+		Long: `Show the rate limits and quotas on your account and on each model, how
+much of each is used, how much headroom is left and when it resets.
+
+Requests that hit a rate limit are retried automatically when throne asks
+for a short wait (up to 10s); otherwise the error says when to try again.
+
+Example:
+  reign dev limits
+  reign dev limits -m llama3.2:3b
+  reign dev limits --json
+`,
+		RunE: runDevLimits,
+	}
+	limitsCmd.Flags().StringP("model", "m", "", "Only show limits for this model (and the account)")
+	limitsCmd.Flags().Bool("json", false, "Output limits as JSON")
+	return limitsCmd
+}
+
+func runDevLimits(cmd *cobra.Command, args []string) error {
+	model, _ := cmd.Flags().GetString("model")
+	asJSON, _ := cmd.Flags().GetBool("json")
+
+	c, err := getThroneClient()
+	if err != nil {
+		return err
+	}
+
+	limits, err := c.GetLimits()
+	if err != nil {
+		return err
+	}
+	if model != "" {
+		limits.Models = slices.DeleteFunc(limits.Models, func(m client.ModelLimits) bool { return m.Model != model })
+		if len(limits.Models) == 0 && !asJSON {
+			fmt.Println(infoStyle.Render(fmt.Sprintf("No model-specific limits for %s; only account limits apply.", model)))
+		}
+	}
+
+	if asJSON {
+		return writeJSON(limits)
+	}
+	fmt.Println(ui.RenderLimits(limits, time.Now()))
+	return nil
+}
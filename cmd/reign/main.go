@@ -1,59 +1,75 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"log/slog"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/sovereynai/reign/internal/bootstrap"
 	"github.com/sovereynai/reign/internal/client"
 	"github.com/sovereynai/reign/internal/config"
+	"github.com/sovereynai/reign/internal/estimate"
+	"github.com/sovereynai/reign/internal/tools"
+	"github.com/sovereynai/reign/internal/tracing"
 	"github.com/sovereynai/reign/internal/ui"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 var (
-	// Styles
-	titleStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(lipgloss.Color("205")).
-			MarginTop(1).
-			MarginBottom(1)
-
-	successStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("42"))
-
-	errorStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("196")).
-			Bold(true)
-
-	infoStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("86"))
+	// Styles (built from the active theme by applyOutputSettings)
+	titleStyle   lipgloss.Style
+	successStyle lipgloss.Style
+	errorStyle   lipgloss.Style
+	infoStyle    lipgloss.Style
 )
 
 func main() {
-	// First-run setup (only happens once)
-	if err := bootstrap.Setup(); err != nil {
-		// Setup failed, but don't block - user might be fixing issues manually
-		// Error already displayed by Setup()
-	}
+	// Apply theme settings from config and environment so help output is
+	// styled; flags are applied (and errors reported) once they are parsed
+	_ = applyOutputSettings(nil)
 
 	rootCmd := &cobra.Command{
 		Use:   "reign",
 		Short: "Sovereyn CLI - Interface for distributed AI inference",
-		Long: titleStyle.Render("👑 Reign") + "\n\n" +
+		Long: titleStyle.Render(ui.WithIcon(ui.Icon.Crown, "Reign")) + "\n\n" +
 			"The command-line interface for Sovereyn's distributed intelligence network.\n" +
 			"Submit inference jobs, manage models, and monitor the network.",
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
-			// Skip throne check for help/version commands
-			if cmd.Name() == "version" || cmd.Name() == "help" || cmd.Name() == "completion" {
+			if err := applyOutputSettings(cmd.Flags()); err != nil {
+				fmt.Fprintln(os.Stderr, infoStyle.Render("Warning: "+err.Error()))
+			}
+
+			// First-run setup (only happens once), after the output flags
+			// are applied so --ascii covers it too
+			if err := bootstrap.Setup(); err != nil {
+				// Setup failed, but don't block - user might be fixing issues manually
+				// Error already displayed by Setup()
+			}
+			setupLogging(cmd.Flags())
+			if err := setupTracing(cmd); err != nil {
+				fmt.Fprintln(os.Stderr, infoStyle.Render("Warning: tracing disabled: "+err.Error()))
+			}
+
+			// Skip throne check for help/version commands, and for the MCP
+			// server, which reports throne being down in tool results
+			if cmd.Name() == "version" || cmd.Name() == "help" || cmd.Name() == "completion" || cmd.Name() == "mcp" {
 				return nil
 			}
 			// Ensure throne daemon is running
 			return bootstrap.EnsureThroneRunning()
 		},
 	}
+	rootCmd.PersistentFlags().String("theme", "", "Color theme (dark, light, high-contrast or a custom theme)")
+	rootCmd.PersistentFlags().Bool("no-color", false, "Disable colors (also honors NO_COLOR)")
+	rootCmd.PersistentFlags().Bool("ascii", false, "Use plain ASCII instead of emoji and box-drawing characters")
+	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "Log throne requests, statuses and timings to stderr")
+	rootCmd.PersistentFlags().Bool("debug", false, "Like --verbose, plus redacted request and response bodies (also REIGN_DEBUG)")
 
 	// Version command
 	versionCmd := &cobra.Command{
@@ -70,6 +86,11 @@ func main() {
 		RunE:  runChat,
 	}
 	chatCmd.Flags().StringP("model", "m", "llama3.2:3b", "Model to use for inference")
+	chatCmd.Flags().Bool("force", false, "Send even if it would exceed a budget")
+	chatCmd.Flags().Bool("dry-run", false, "Estimate tokens, cost and queue wait without sending")
+	chatCmd.Flags().Bool("json", false, "Output the --dry-run estimate as JSON")
+	chatCmd.Flags().String("tools", "", "YAML file of tools the model may call")
+	chatCmd.Flags().BoolP("yes", "y", false, "Run tool calls without asking")
 
 	// Models command
 	modelsCmd := &cobra.Command{
@@ -96,6 +117,7 @@ func main() {
 		Short: "Show comprehensive dashboard (auto-detects role)",
 		RunE:  runStatus,
 	}
+	statusCmd.Flags().String("export", "", "Write the dashboard to a static report (.html, .svg or .md)")
 
 	// Dev subcommand
 	devCmd := &cobra.Command{
@@ -107,22 +129,7 @@ func main() {
 		Short: "Show AI Developer dashboard",
 		RunE:  runDevStatus,
 	}
-	devHistoryCmd := &cobra.Command{
-		Use:   "history",
-		Short: "View request history (coming soon)",
-		RunE:  runComingSoon,
-	}
-	devOptimizeCmd := &cobra.Command{
-		Use:   "optimize",
-		Short: "Get cost optimization suggestions (coming soon)",
-		RunE:  runComingSoon,
-	}
-	devPlaygroundCmd := &cobra.Command{
-		Use:   "playground",
-		Short: "Interactive model testing (coming soon)",
-		RunE:  runComingSoon,
-	}
-	devCmd.AddCommand(devStatusCmd, devHistoryCmd, devOptimizeCmd, devPlaygroundCmd)
+	devCmd.AddCommand(devStatusCmd, createDevHistoryCommand(), createDevOptimizeCommand(), createDevPlaygroundCommand(), createDevLimitsCommand())
 
 	// Node subcommand
 	nodeCmd := &cobra.Command{
@@ -162,26 +169,80 @@ func main() {
 	nodeJobsCmd := &cobra.Command{
 		Use:   "jobs",
 		Short: "View live inference jobs with progress bars",
-		Run: func(cmd *cobra.Command, args []string) {
-			if err := ui.ShowLiveJobs(); err != nil {
-				fmt.Println(errorStyle.Render("❌ Live jobs viewer requires a terminal (TTY)"))
-				fmt.Println(infoStyle.Render("💡 Tip: Use 'reign node status' for a snapshot view"))
-			}
-		},
+		RunE:  runJobs,
 	}
+	addJobsFlags(nodeJobsCmd)
 	nodeCmd.AddCommand(nodeStatusCmd, nodeEarningsCmd, nodeOptimizeCmd, nodeModelsCmd, nodePeersCmd, nodeLogsCmd, nodeJobsCmd)
 
 	// Register jobs command (also available as top-level command)
 	RegisterJobsCommand(rootCmd)
 
-	rootCmd.AddCommand(versionCmd, chatCmd, modelsCmd, statusCmd, devCmd, nodeCmd)
+	rootCmd.AddCommand(versionCmd, chatCmd, modelsCmd, statusCmd, devCmd, nodeCmd, createStatsCommand(), createEventsCommand(), createExporterCommand(), createBudgetCommand(), createBatchCommand(), createServeCommand(), createMCPCommand())
+
+	err := rootCmd.Execute()
+	tracing.RootSpan().SetError(err)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	if traceErr := tracing.Shutdown(ctx); traceErr != nil {
+		fmt.Fprintln(os.Stderr, infoStyle.Render("Warning: "+traceErr.Error()))
+	}
+	cancel()
 
-	if err := rootCmd.Execute(); err != nil {
+	if err != nil {
 		fmt.Fprintln(os.Stderr, errorStyle.Render("Error: "+err.Error()))
 		os.Exit(1)
 	}
 }
 
+// setupLogging logs throne HTTP traffic to stderr when asked to by
+// --verbose, --debug or REIGN_DEBUG
+func setupLogging(flags *pflag.FlagSet) {
+	verbose, _ := flags.GetBool("verbose")
+	debug, _ := flags.GetBool("debug")
+	if os.Getenv("REIGN_DEBUG") != "" {
+		debug = true
+	}
+	if !verbose && !debug {
+		return
+	}
+
+	level := slog.LevelInfo
+	if debug {
+		level = slog.LevelDebug
+	}
+	client.SetLogger(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level})))
+}
+
+// setupTracing enables trace export from the settings file or the standard
+// OpenTelemetry environment variables and starts the command's root span
+func setupTracing(cmd *cobra.Command) error {
+	settings, err := config.LoadSettings()
+	if err != nil {
+		settings = &config.Settings{}
+	}
+
+	cfg := tracing.Config{
+		Endpoint:    settings.Tracing.Endpoint,
+		File:        settings.Tracing.File,
+		ServiceName: os.Getenv("OTEL_SERVICE_NAME"),
+		Version:     "v0.2.1",
+		Parent:      os.Getenv("TRACEPARENT"),
+	}
+	if env := os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"); env != "" {
+		cfg.Endpoint, cfg.File = env, ""
+	} else if env := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT"); env != "" {
+		cfg.Endpoint, cfg.File = env, ""
+	}
+	if env := os.Getenv("REIGN_TRACE_FILE"); env != "" {
+		cfg.File = env
+	}
+
+	if err := tracing.Init(cfg); err != nil {
+		return err
+	}
+	tracing.StartRoot(cmd.CommandPath())
+	return nil
+}
+
 func getThroneClient() (*client.ThroneClient, error) {
 	cfg, err := config.Load()
 	if err != nil {
@@ -201,7 +262,7 @@ func runVersion(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get version: %w", err)
 	}
 
-	fmt.Println(titleStyle.Render("👑 Sovereyn"))
+	fmt.Println(titleStyle.Render(ui.WithIcon(ui.Icon.Crown, "Sovereyn")))
 	fmt.Println(infoStyle.Render("Daemon Version:  ") + version.Version)
 	fmt.Println(infoStyle.Render("Commit:          ") + version.Commit[:8])
 	fmt.Println(infoStyle.Render("Build Time:      ") + version.BuildTime)
@@ -212,20 +273,54 @@ func runVersion(cmd *cobra.Command, args []string) error {
 
 func runChat(cmd *cobra.Command, args []string) error {
 	model, _ := cmd.Flags().GetString("model")
+	force, _ := cmd.Flags().GetBool("force")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	asJSON, _ := cmd.Flags().GetBool("json")
+	toolsFile, _ := cmd.Flags().GetString("tools")
+	yes, _ := cmd.Flags().GetBool("yes")
 	prompt := strings.Join(args, " ")
 
+	var specs []tools.Spec
+	if toolsFile != "" {
+		var err error
+		if specs, err = tools.Load(toolsFile); err != nil {
+			return err
+		}
+	}
+
 	c, err := getThroneClient()
 	if err != nil {
 		return err
 	}
 
+	req := client.ChatRequest{
+		Model:    model,
+		Messages: []client.ChatMessage{{Role: "user", Content: prompt}},
+	}
+	if dryRun {
+		e := estimate.Make(estimateInput(c, req))
+		if asJSON {
+			return writeJSON(e)
+		}
+		printEstimate(e, req)
+		return nil
+	}
+	if err := checkBudget(req, force); err != nil {
+		return err
+	}
+	if len(specs) > 0 {
+		return runChatWithTools(c, req, specs, force, yes)
+	}
+
 	// Show we're working
-	fmt.Println(infoStyle.Render("🤖 Submitting to throne daemon..."))
-	fmt.Println(infoStyle.Render("📝 Model: ") + model)
-	fmt.Println(infoStyle.Render("💬 Prompt: ") + prompt)
+	fmt.Println(infoStyle.Render(ui.WithIcon(ui.Icon.Robot, "Submitting to throne daemon...")))
+	fmt.Println(infoStyle.Render(ui.WithIcon(ui.Icon.Memo, "Model: ")) + model)
+	fmt.Println(infoStyle.Render(ui.WithIcon(ui.Icon.Speech, "Prompt: ")) + prompt)
 	fmt.Println()
 
-	resp, err := c.Chat(model, prompt)
+	start := time.Now()
+	resp, err := c.SendChat(req)
+	recordRequest(req, resp, err, time.Since(start))
 	if err != nil {
 		return fmt.Errorf("inference failed: %w", err)
 	}
@@ -235,7 +330,7 @@ func runChat(cmd *cobra.Command, args []string) error {
 	}
 
 	// Display response
-	fmt.Println(titleStyle.Render("✨ Response"))
+	fmt.Println(titleStyle.Render(ui.WithIcon(ui.Icon.Sparkles, "Response")))
 	if resp.Message.Content != "" {
 		fmt.Println(resp.Message.Content)
 	} else {
@@ -243,7 +338,7 @@ func runChat(cmd *cobra.Command, args []string) error {
 	}
 
 	fmt.Println()
-	fmt.Println(infoStyle.Render(fmt.Sprintf("⚡ Latency: %dms", resp.LatencyMs)))
+	fmt.Println(infoStyle.Render(ui.WithIcon(ui.Icon.Speed, fmt.Sprintf("Latency: %dms", resp.LatencyMs))))
 
 	return nil
 }
@@ -259,9 +354,9 @@ func runModels(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to list models: %w", err)
 	}
 
-	fmt.Println(titleStyle.Render("📦 Local Models"))
+	fmt.Println(titleStyle.Render(ui.WithIcon(ui.Icon.Package, "Local Models")))
 	for _, model := range models {
-		fmt.Println(successStyle.Render("  • ") + model)
+		fmt.Println(successStyle.Render("  "+ui.Icon.Bullet+" ") + model)
 	}
 
 	return nil
@@ -278,7 +373,7 @@ func runModelsNetwork(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to list network models: %w", err)
 	}
 
-	fmt.Println(titleStyle.Render("🌍 Network Models"))
+	fmt.Println(titleStyle.Render(ui.WithIcon(ui.Icon.Globe, "Network Models")))
 	fmt.Println()
 
 	if len(models) == 0 {
@@ -299,10 +394,10 @@ func runModelsNetwork(cmd *cobra.Command, args []string) error {
 
 	// Display Ollama models
 	if len(ollamaModels) > 0 {
-		fmt.Println(successStyle.Render(fmt.Sprintf("🤖 Ollama Models (%d)", len(ollamaModels))))
+		fmt.Println(successStyle.Render(ui.WithIcon(ui.Icon.Robot, fmt.Sprintf("Ollama Models (%d)", len(ollamaModels)))))
 		for _, model := range ollamaModels {
 			fmt.Printf("  %s %s\n",
-				successStyle.Render("• "+model.Name),
+				successStyle.Render(ui.Icon.Bullet+" "+model.Name),
 				infoStyle.Render(fmt.Sprintf("(%s)", model.Category)))
 
 			trackerInfo := ""
@@ -327,17 +422,17 @@ func runModelsNetwork(cmd *cobra.Command, args []string) error {
 			}
 
 			if localLoc != nil {
-				fmt.Println(infoStyle.Render("      ✓ Local (this node)"))
+				fmt.Println(infoStyle.Render("      " + ui.Icon.Check + " Local (this node)"))
 			}
 			for _, tracker := range trackers {
-				fmt.Println(infoStyle.Render(fmt.Sprintf("      📍 Tracker: %s", tracker.TrackerName)))
+				fmt.Println(infoStyle.Render("      " + ui.WithIcon(ui.Icon.Pin, "Tracker: "+tracker.TrackerName)))
 			}
 			for _, remote := range remotes {
-				fmt.Println(infoStyle.Render(fmt.Sprintf("      🌐 Node: %s", remote.NodeID)))
+				fmt.Println(infoStyle.Render("      " + ui.WithIcon(ui.Icon.Network, "Node: "+remote.NodeID)))
 			}
 
 			if model.PullCommand != "" {
-				fmt.Println(infoStyle.Render(fmt.Sprintf("    💾 Pull: %s", model.PullCommand)))
+				fmt.Println(infoStyle.Render("    " + ui.WithIcon(ui.Icon.Disk, "Pull: "+model.PullCommand)))
 			}
 			fmt.Println()
 		}
@@ -345,10 +440,10 @@ func runModelsNetwork(cmd *cobra.Command, args []string) error {
 
 	// Display ONNX models
 	if len(onnxModels) > 0 {
-		fmt.Println(successStyle.Render(fmt.Sprintf("🔬 ONNX Models (%d)", len(onnxModels))))
+		fmt.Println(successStyle.Render(ui.WithIcon(ui.Icon.Microscope, fmt.Sprintf("ONNX Models (%d)", len(onnxModels)))))
 		for _, model := range onnxModels {
 			fmt.Printf("  %s %s\n",
-				successStyle.Render("• "+model.Name),
+				successStyle.Render(ui.Icon.Bullet+" "+model.Name),
 				infoStyle.Render(fmt.Sprintf("(%s)", model.Category)))
 
 			trackerInfo := ""
@@ -373,17 +468,17 @@ func runModelsNetwork(cmd *cobra.Command, args []string) error {
 			}
 
 			if localLoc != nil {
-				fmt.Println(infoStyle.Render("      ✓ Local (this node)"))
+				fmt.Println(infoStyle.Render("      " + ui.Icon.Check + " Local (this node)"))
 			}
 			for _, tracker := range trackers {
-				fmt.Println(infoStyle.Render(fmt.Sprintf("      📍 Tracker: %s", tracker.TrackerName)))
+				fmt.Println(infoStyle.Render("      " + ui.WithIcon(ui.Icon.Pin, "Tracker: "+tracker.TrackerName)))
 			}
 			for _, remote := range remotes {
-				fmt.Println(infoStyle.Render(fmt.Sprintf("      🌐 Node: %s", remote.NodeID)))
+				fmt.Println(infoStyle.Render("      " + ui.WithIcon(ui.Icon.Network, "Node: "+remote.NodeID)))
 			}
 
 			if model.PullCommand != "" {
-				fmt.Println(infoStyle.Render(fmt.Sprintf("    💾 Pull: %s", model.PullCommand)))
+				fmt.Println(infoStyle.Render("    " + ui.WithIcon(ui.Icon.Disk, "Pull: "+model.PullCommand)))
 			}
 			fmt.Println()
 		}
@@ -408,7 +503,7 @@ func runModelsLocate(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to locate model: %w", err)
 	}
 
-	fmt.Println(titleStyle.Render(fmt.Sprintf("📍 Locations for %s", modelName)))
+	fmt.Println(titleStyle.Render(ui.WithIcon(ui.Icon.Pin, fmt.Sprintf("Locations for %s", modelName))))
 	fmt.Println()
 
 	if len(locations) == 0 {
@@ -421,10 +516,10 @@ func runModelsLocate(cmd *cobra.Command, args []string) error {
 		locType := loc["type"].(string)
 
 		if locType == "local" {
-			fmt.Println(successStyle.Render("  ✓ Local (this node)"))
+			fmt.Println(successStyle.Render("  " + ui.Icon.Check + " Local (this node)"))
 			fmt.Println(infoStyle.Render(fmt.Sprintf("    URL: %s", loc["url"])))
 		} else {
-			fmt.Println(successStyle.Render(fmt.Sprintf("  ✓ Remote node: %s", nodeID)))
+			fmt.Println(successStyle.Render(fmt.Sprintf("  %s Remote node: %s", ui.Icon.Check, nodeID)))
 			fmt.Println(infoStyle.Render(fmt.Sprintf("    URL: %s", loc["url"])))
 			if latency, ok := loc["latency_ms"]; ok && latency.(float64) > 0 {
 				fmt.Println(infoStyle.Render(fmt.Sprintf("    Latency: %.0fms", latency)))
@@ -446,18 +541,24 @@ func runStatus(cmd *cobra.Command, args []string) error {
 		// Fallback to simple status if dashboard endpoint not available
 		return runSimpleStatus(c)
 	}
+	recordStats(stats)
+	trends := loadTrends()
+
+	if path, _ := cmd.Flags().GetString("export"); path != "" {
+		return exportReport(path, stats, trends)
+	}
 
 	// Auto-detect role and show appropriate dashboard
 	switch stats.Role {
 	case "developer":
-		fmt.Println(ui.RenderDeveloperDashboard(stats))
+		fmt.Println(ui.RenderDeveloperDashboard(stats, trends))
 	case "operator":
-		fmt.Println(ui.RenderOperatorDashboard(stats))
+		fmt.Println(ui.RenderOperatorDashboard(stats, trends))
 	case "both":
 		// Show both dashboards
-		fmt.Println(ui.RenderDeveloperDashboard(stats))
+		fmt.Println(ui.RenderDeveloperDashboard(stats, trends))
 		fmt.Println()
-		fmt.Println(ui.RenderOperatorDashboard(stats))
+		fmt.Println(ui.RenderOperatorDashboard(stats, trends))
 	default:
 		return runSimpleStatus(c)
 	}
@@ -465,6 +566,25 @@ func runStatus(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func exportReport(path string, stats *client.DashboardStats, trends *ui.Trends) error {
+	format, err := ui.ExportFormatForPath(path)
+	if err != nil {
+		return err
+	}
+
+	report, err := ui.ExportDashboards(stats, trends, format)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(path, []byte(report), 0644); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+
+	fmt.Println(successStyle.Render(ui.Icon.Check+" Report written to ") + path)
+	return nil
+}
+
 func runDevStatus(cmd *cobra.Command, args []string) error {
 	c, err := getThroneClient()
 	if err != nil {
@@ -479,8 +599,10 @@ func runDevStatus(cmd *cobra.Command, args []string) error {
 	if stats.Developer == nil {
 		return fmt.Errorf("no developer stats available - have you made any inference requests?")
 	}
+	recordStats(stats)
+	trends := loadTrends()
 
-	fmt.Println(ui.RenderDeveloperDashboard(stats))
+	fmt.Println(ui.RenderDeveloperDashboard(stats, trends))
 	return nil
 }
 
@@ -498,13 +620,15 @@ func runNodeStatus(cmd *cobra.Command, args []string) error {
 	if stats.Operator == nil {
 		return fmt.Errorf("no operator stats available - is this node serving models?")
 	}
+	recordStats(stats)
+	trends := loadTrends()
 
-	fmt.Println(ui.RenderOperatorDashboard(stats))
+	fmt.Println(ui.RenderOperatorDashboard(stats, trends))
 	return nil
 }
 
 func runComingSoon(cmd *cobra.Command, args []string) error {
-	fmt.Println(infoStyle.Render("🚧 Coming soon!"))
+	fmt.Println(infoStyle.Render(ui.WithIcon(ui.Icon.Construction, "Coming soon!")))
 	fmt.Println("This feature is under active development.")
 	return nil
 }
@@ -512,7 +636,7 @@ func runComingSoon(cmd *cobra.Command, args []string) error {
 // Fallback for older throne versions without dashboard endpoint
 func runSimpleStatus(c *client.ThroneClient) error {
 	if err := c.Health(); err != nil {
-		fmt.Println(errorStyle.Render("❌ Throne daemon: OFFLINE"))
+		fmt.Println(errorStyle.Render(ui.WithIcon(ui.Icon.Fail, "Throne daemon: OFFLINE")))
 		return err
 	}
 
@@ -521,20 +645,80 @@ func runSimpleStatus(c *client.ThroneClient) error {
 		return err
 	}
 
-	fmt.Println(titleStyle.Render("🏛️  Throne Daemon Status"))
-	fmt.Println(successStyle.Render("✅ Status:  ") + "ONLINE")
-	fmt.Println(infoStyle.Render("🔖 Version: ") + version.Version)
-	fmt.Println(infoStyle.Render("🌐 URL:     ") + c.BaseURL)
+	fmt.Println(titleStyle.Render(ui.WithIcon(ui.Icon.Throne, "Throne Daemon Status")))
+	fmt.Println(successStyle.Render(ui.WithIcon(ui.Icon.OK, "Status:  ")) + "ONLINE")
+	fmt.Println(infoStyle.Render(ui.WithIcon(ui.Icon.Tag, "Version: ")) + version.Version)
+	fmt.Println(infoStyle.Render(ui.WithIcon(ui.Icon.Network, "URL:     ")) + c.BaseURL)
 
 	// Show available models
 	models, err := c.ListModels()
 	if err == nil && len(models) > 0 {
 		fmt.Println()
-		fmt.Println(infoStyle.Render("📦 Models:  ") + fmt.Sprintf("%d available", len(models)))
+		fmt.Println(infoStyle.Render(ui.WithIcon(ui.Icon.Package, "Models:  ")) + fmt.Sprintf("%d available", len(models)))
 	}
 
 	fmt.Println()
-	fmt.Println(infoStyle.Render("💡 Tip: Upgrade throne for rich dashboards with detailed metrics"))
+	fmt.Println(infoStyle.Render(ui.WithIcon(ui.Icon.Tip, "Tip: Upgrade throne for rich dashboards with detailed metrics")))
 
 	return nil
 }
+
+// applyOutputSettings applies the theme, color and ASCII preferences from the
+// settings file, the environment and (when given) command-line flags. Errors
+// are returned for reporting; the defaults are used in their place.
+func applyOutputSettings(flags *pflag.FlagSet) error {
+	settings, err := config.LoadSettings()
+	if err != nil {
+		settings = &config.Settings{}
+	}
+
+	themeName := settings.Theme
+	if env := os.Getenv("REIGN_THEME"); env != "" {
+		themeName = env
+	}
+	noColor := settings.NoColor || os.Getenv("NO_COLOR") != ""
+	ascii := settings.ASCII || os.Getenv("REIGN_ASCII") != ""
+
+	if flags != nil {
+		if flags.Changed("theme") {
+			themeName, _ = flags.GetString("theme")
+		}
+		if v, _ := flags.GetBool("no-color"); v {
+			noColor = true
+		}
+		if v, _ := flags.GetBool("ascii"); v {
+			ascii = true
+		}
+	}
+
+	theme, themeErr := ui.LookupTheme(themeName, settings.Themes)
+	if themeErr != nil {
+		theme = ui.DarkTheme
+	}
+	ui.UseTheme(theme)
+	ui.UseASCII(ascii)
+	if noColor {
+		ui.DisableColor()
+	}
+
+	t := ui.CurrentTheme()
+	titleStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(t.Primary).
+		MarginTop(1).
+		MarginBottom(1)
+
+	successStyle = lipgloss.NewStyle().
+		Foreground(t.Success)
+
+	errorStyle = lipgloss.NewStyle().
+		Foreground(t.Error).
+		Bold(true)
+
+	infoStyle = lipgloss.NewStyle().
+		Foreground(t.Label)
+
+	layoutErr := ui.UseLayout(settings.Layout)
+
+	return errors.Join(err, themeErr, layoutErr)
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+
+	"github.com/sovereynai/reign/internal/mcp"
+	"github.com/spf13/cobra"
+)
+
+func createMCPCommand() *cobra.Command {
+	mcpCmd := &cobra.Command{
+		Use:   "mcp",
+		Short: "Run a Model Context Protocol server on stdio",
+		Long: `Serve Sovereyn tools over the Model Context Protocol on stdin and stdout,
+so agents and editors can delegate sub-tasks to network models and query the
+network. Tools:
+
+  chat             Send a prompt to a model and return its reply
+  list_models      Local models and those available on the network
+  locate_model     Nodes hosting a model, with latency and price
+  dashboard_stats  Credits, spending, network health and queue depth
+  vision_classify  Classify an image with a vision model
+
+Chat requests count towards budgets and are saved to the request history.
+Requests are logged to stderr.
+
+Example MCP client configuration:
+  {"mcpServers": {"sovereyn": {"command": "reign", "args": ["mcp"]}}}
+`,
+		Args: cobra.NoArgs,
+		RunE: runMCP,
+	}
+	mcpCmd.Flags().Bool("force", false, "Send requests even if they would exceed a budget")
+	return mcpCmd
+}
+
+func runMCP(cmd *cobra.Command, args []string) error {
+	force, _ := cmd.Flags().GetBool("force")
+
+	c, err := getThroneClient()
+	if err != nil {
+		return err
+	}
+
+	hooks := newRequestHooks(os.Stderr, force)
+	opts := mcp.Options{Check: hooks.Check, OnChat: hooks.OnChat, OnVision: hooks.OnVision}
+
+	server := &mcp.Server{Name: "reign", Version: "v0.2.1", Tools: mcp.Tools(c, opts)}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	return server.Serve(ctx, os.Stdin, os.Stdout)
+}
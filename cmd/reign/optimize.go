@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/sovereynai/reign/internal/client"
+	"github.com/sovereynai/reign/internal/history"
+	"github.com/sovereynai/reign/internal/optimize"
+	"github.com/sovereynai/reign/internal/stats"
+	"github.com/sovereynai/reign/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+func createDevOptimizeCommand() *cobra.Command {
+	optimizeCmd := &cobra.Command{
+		Use:   "optimize",
+		Short: "Get cost optimization suggestions",
+		Long: `Analyze your model usage, latency and local request history for ways
+to spend fewer credits. Each suggestion comes with estimated savings and a
+command to apply or try it.
+
+Example:
+  reign dev optimize
+  reign dev optimize --since 30d --json
+`,
+		RunE: runDevOptimize,
+	}
+	optimizeCmd.Flags().String("since", "7d", "How much request history to analyze (e.g. 24h, 7d, 30d)")
+	optimizeCmd.Flags().Bool("json", false, "Output suggestions as JSON")
+	return optimizeCmd
+}
+
+func runDevOptimize(cmd *cobra.Command, args []string) error {
+	since, _ := cmd.Flags().GetString("since")
+	asJSON, _ := cmd.Flags().GetBool("json")
+
+	window, err := stats.ParseWindow(since)
+	if err != nil {
+		return err
+	}
+
+	c, err := getThroneClient()
+	if err != nil {
+		return err
+	}
+
+	in := optimize.Input{Window: window}
+	dashboard, err := c.GetDashboardStats()
+	if err != nil {
+		return fmt.Errorf("failed to get dashboard stats: %w", err)
+	}
+	in.Stats = dashboard.Developer
+
+	// Local and network models and history refine the analysis but aren't
+	// required
+	if models, err := c.ListModels(); err == nil {
+		in.LocalModels = models
+	}
+	if models, err := c.ListNetworkModels(); err == nil {
+		in.NetworkModels = models
+	}
+	in.History, err = history.DefaultStore().Entries(history.Filter{Since: time.Now().Add(-window)})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, infoStyle.Render("Could not read request history: "+err.Error()))
+	}
+
+	suggestions := optimize.Analyze(in)
+	if asJSON {
+		if suggestions == nil {
+			suggestions = []optimize.Suggestion{}
+		}
+		return writeJSON(suggestions)
+	}
+
+	fmt.Println(titleStyle.Render(ui.WithIcon(ui.Icon.Target, "Cost Optimization")))
+	if len(suggestions) == 0 {
+		fmt.Println(successStyle.Render(ui.WithIcon(ui.Icon.Check, "No savings found - your usage already looks efficient.")))
+		return nil
+	}
+
+	for i, s := range suggestions {
+		fmt.Printf("%2d. %s  %s\n", i+1, successStyle.Render(s.Title), infoStyle.Render(fmt.Sprintf("~%.4f credits/day", s.SavingsPerDay)))
+		fmt.Printf("    %s\n", s.Detail)
+		fmt.Printf("    %s %s\n\n", infoStyle.Render(ui.Icon.Arrow), s.Command)
+	}
+
+	printSavingsSummary(in.Stats, optimize.TotalSavings(suggestions))
+	return nil
+}
+
+// printSavingsSummary shows the combined savings against the current burn
+// rate and credit runway
+func printSavingsSummary(dev *client.DeveloperStats, savings float64) {
+	fmt.Println(infoStyle.Render("Potential savings: ") + fmt.Sprintf("~%.4f credits/day", savings))
+	if dev == nil || dev.Credits.BurnRate <= 0 {
+		return
+	}
+
+	burn := dev.Credits.BurnRate
+	newBurn := max(0, burn-savings)
+	fmt.Println(infoStyle.Render("Burn rate:         ") + fmt.Sprintf("%.2f %s %.2f credits/day (-%.0f%%)", burn, ui.Icon.Arrow, newBurn, min(100, savings/burn*100)))
+	if newBurn > 0 && dev.Credits.Balance > 0 {
+		fmt.Println(infoStyle.Render("Runway:            ") + fmt.Sprintf("%d %s %.0f days", dev.Credits.RunwayDays, ui.Icon.Arrow, dev.Credits.Balance/newBurn))
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sovereynai/reign/internal/budget"
+	"github.com/sovereynai/reign/internal/client"
+	"github.com/sovereynai/reign/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+func createDevPlaygroundCommand() *cobra.Command {
+	playgroundCmd := &cobra.Command{
+		Use:   "playground",
+		Short: "Interactive model testing",
+		Long: `Try prompts interactively. Edit the prompt and parameters, then run the
+prompt against up to 3 models side by side. Output streams in as it is
+generated, and each model's latency and cost are shown when it finishes.
+
+Runs are saved to the local request history.
+
+Example:
+  reign dev playground
+  reign dev playground -m llama3.2:3b -m qwen2.5:7b --temperature 0.2
+`,
+		RunE: runDevPlayground,
+	}
+	playgroundCmd.Flags().StringSliceP("model", "m", []string{"llama3.2:3b"}, "Models to compare (up to 3)")
+	playgroundCmd.Flags().Float64("temperature", 0, "Sampling temperature (default: the model's)")
+	playgroundCmd.Flags().Int("max-tokens", 0, "Maximum tokens to generate (default: the model's)")
+	playgroundCmd.Flags().String("system", "", "System prompt")
+	playgroundCmd.Flags().Bool("force", false, "Send even if it would exceed a budget")
+	return playgroundCmd
+}
+
+func runDevPlayground(cmd *cobra.Command, args []string) error {
+	models, _ := cmd.Flags().GetStringSlice("model")
+	system, _ := cmd.Flags().GetString("system")
+	force, _ := cmd.Flags().GetBool("force")
+
+	var mu sync.Mutex // Results come in concurrently
+	opts := ui.PlaygroundOptions{
+		Models: models,
+		System: system,
+		OnResult: func(req client.ChatRequest, resp *client.ChatResponse, err error, elapsed time.Duration) {
+			mu.Lock()
+			defer mu.Unlock()
+			recordRequest(req, resp, err, elapsed)
+		},
+	}
+	if !force {
+		opts.BeforeRun = checkBudgetRun
+	}
+	if cmd.Flags().Changed("temperature") {
+		temperature, _ := cmd.Flags().GetFloat64("temperature")
+		opts.Temperature = strconv.FormatFloat(temperature, 'f', -1, 64)
+	}
+	if maxTokens, _ := cmd.Flags().GetInt("max-tokens"); maxTokens > 0 {
+		opts.MaxTokens = strconv.Itoa(maxTokens)
+	}
+
+	c, err := getThroneClient()
+	if err != nil {
+		return err
+	}
+	return ui.ShowPlayground(c, opts)
+}
+
+// checkBudgetRun checks requests that are sent together against the budgets,
+// each counting the estimated cost of those before it
+func checkBudgetRun(reqs []client.ChatRequest) error {
+	var pending []budget.Spend
+	for _, req := range reqs {
+		_, estimate, err := evaluateBudgetPending(req, nil, pending)
+		if err != nil {
+			return fmt.Errorf("%s: %w", req.Model, err)
+		}
+		pending = append(pending, budget.Spend{Time: time.Now().UTC(), Model: req.Model, Project: budget.CurrentProject(), Cost: estimate})
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"time"
+
+	"github.com/sovereynai/reign/internal/client"
+	"github.com/sovereynai/reign/internal/proxy"
+	"github.com/sovereynai/reign/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+func createServeCommand() *cobra.Command {
+	serveCmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve other model APIs backed by the Sovereyn network",
+		Long: `Serve a local API that other tools already speak, translating requests to
+throne. Requests count towards budgets and are saved to the request history.
+
+--openai serves the OpenAI API: /v1/chat/completions (including streaming),
+/v1/models and /v1/embeddings. Point an SDK or IDE plugin at
+http://127.0.0.1:11500/v1 with any API key.
+
+--ollama serves the Ollama API: /api/chat, /api/generate, /api/embed and
+/api/tags. Tags include the network's models as well as local ones. Point
+tools at it with OLLAMA_HOST=127.0.0.1:11500.
+
+Both can be served at once.
+
+There is no authentication, so keep the listen address on localhost unless
+the network is trusted.
+
+Example:
+  reign serve --openai
+  reign serve --ollama
+  reign serve --openai --ollama --listen 127.0.0.1:8080
+`,
+		RunE: runServe,
+	}
+	serveCmd.Flags().Bool("openai", false, "Serve the OpenAI API")
+	serveCmd.Flags().Bool("ollama", false, "Serve the Ollama API")
+	serveCmd.Flags().String("listen", "127.0.0.1:11500", "Address to listen on")
+	serveCmd.Flags().Bool("force", false, "Send requests even if they would exceed a budget")
+	return serveCmd
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	openAI, _ := cmd.Flags().GetBool("openai")
+	ollama, _ := cmd.Flags().GetBool("ollama")
+	listen, _ := cmd.Flags().GetString("listen")
+	force, _ := cmd.Flags().GetBool("force")
+	if !openAI && !ollama {
+		return fmt.Errorf("choose an API to serve with --openai and/or --ollama")
+	}
+
+	c, err := getThroneClient()
+	if err != nil {
+		return err
+	}
+
+	hooks := newRequestHooks(os.Stdout, force)
+	opts := proxy.Options{Check: hooks.Check, OnChat: hooks.OnChat, OnEmbed: hooks.OnEmbed}
+	mux := http.NewServeMux()
+	if openAI {
+		mux.Handle("/v1/", proxy.NewOpenAI(c, opts))
+	}
+	if ollama {
+		mux.Handle("/", proxy.NewOllama(c, opts))
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	server := &http.Server{Addr: listen, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	if openAI {
+		fmt.Println(successStyle.Render(ui.WithIcon(ui.Icon.Network, fmt.Sprintf("Serving the OpenAI API on http://%s/v1", displayAddr(listen)))))
+	}
+	if ollama {
+		fmt.Println(successStyle.Render(ui.WithIcon(ui.Icon.Network, fmt.Sprintf("Serving the Ollama API on http://%s", displayAddr(listen)))))
+	}
+	fmt.Println(infoStyle.Render(fmt.Sprintf("Sending requests to %s (Ctrl+C to stop)", c.BaseURL)))
+
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to serve: %w", err)
+	}
+	return nil
+}
+
+// requestHooks are the hooks around requests sent on behalf of other tools,
+// shared by reign serve and reign mcp
+type requestHooks struct {
+	Check    func(req client.ChatRequest) (release func(), err error)
+	OnChat   func(req client.ChatRequest, resp *client.ChatResponse, err error, elapsed time.Duration)
+	OnEmbed  func(req client.EmbedRequest, resp *client.EmbedResponse, err error, elapsed time.Duration)
+	OnVision func(req client.VisionRequest, resp *client.VisionResponse, err error, elapsed time.Duration)
+}
+
+// newRequestHooks checks requests against budgets unless force is set,
+// counting those in flight, records them and logs each one on a line to w
+func newRequestHooks(w io.Writer, force bool) requestHooks {
+	var mu sync.Mutex // Requests are handled concurrently
+	hooks := requestHooks{
+		OnChat: func(req client.ChatRequest, resp *client.ChatResponse, err error, elapsed time.Duration) {
+			mu.Lock()
+			defer mu.Unlock()
+			recordRequest(req, resp, err, elapsed)
+			var cost float64
+			if resp != nil {
+				cost = resp.Cost
+			}
+			logProxyRequest(w, "chat", req.Model, elapsed, cost, err)
+		},
+		OnEmbed: func(req client.EmbedRequest, resp *client.EmbedResponse, err error, elapsed time.Duration) {
+			mu.Lock()
+			defer mu.Unlock()
+			recordEmbedRequest(req, resp, err, elapsed)
+			var cost float64
+			if resp != nil {
+				cost = resp.Cost
+			}
+			logProxyRequest(w, "embed", req.Model, elapsed, cost, err)
+		},
+		OnVision: func(req client.VisionRequest, resp *client.VisionResponse, err error, elapsed time.Duration) {
+			mu.Lock()
+			defer mu.Unlock()
+			recordVisionRequest(req, resp, err, elapsed)
+			var cost float64
+			if resp != nil {
+				cost = resp.Cost
+			}
+			logProxyRequest(w, "vision", req.Model, elapsed, cost, err)
+		},
+	}
+	if !force {
+		pending := &pendingSpend{}
+		hooks.Check = func(req client.ChatRequest) (func(), error) {
+			release, err := pending.reserve(req)
+			if err != nil {
+				logProxyRequest(w, "refused", req.Model, 0, 0, err)
+			}
+			return release, err
+		}
+	}
+	return hooks
+}
+
+// logProxyRequest writes a line about a request sent on behalf of a client
+func logProxyRequest(w io.Writer, kind, model string, elapsed time.Duration, cost float64, err error) {
+	line := fmt.Sprintf("%s  %-7s %-24s", time.Now().Format("15:04:05"), kind, model)
+	if err != nil {
+		fmt.Fprintln(w, errorStyle.Render(line+"  "+err.Error()))
+		return
+	}
+	fmt.Fprintf(w, "%s  %6dms  %.4f credits\n", line, elapsed.Milliseconds(), cost)
+}
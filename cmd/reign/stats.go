@@ -0,0 +1,230 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/sovereynai/reign/internal/client"
+	"github.com/sovereynai/reign/internal/config"
+	"github.com/sovereynai/reign/internal/stats"
+	"github.com/sovereynai/reign/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+func createStatsCommand() *cobra.Command {
+	statsCmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Record and query local history of dashboard stats",
+		Long: `Record snapshots of dashboard stats to a local append-only store
+(~/.sovereyn/stats/history.jsonl) and query how metrics change over time,
+independent of throne restarts.
+
+Set "record_stats": true in ~/.sovereyn/reign.json to record a snapshot
+every time a dashboard is shown.
+
+Example:
+  reign stats record                           # Record one snapshot
+  reign stats record --every 5m                # Keep recording every 5 minutes
+  reign stats history --metric burn_rate --since 7d
+  reign stats metrics                          # List queryable metrics
+`,
+	}
+
+	recordCmd := &cobra.Command{
+		Use:   "record",
+		Short: "Record a snapshot of the current dashboard stats",
+		RunE:  runStatsRecord,
+	}
+	recordCmd.Flags().Duration("every", 0, "Keep recording at this interval until interrupted")
+
+	historyCmd := &cobra.Command{
+		Use:   "history",
+		Short: "Show recorded values of a metric",
+		RunE:  runStatsHistory,
+	}
+	historyCmd.Flags().StringP("metric", "M", "burn_rate", "Metric to show (see 'reign stats metrics')")
+	historyCmd.Flags().String("since", "7d", "How far back to look (e.g. 24h, 7d, 2w)")
+	historyCmd.Flags().Bool("json", false, "Output points as JSON")
+
+	metricsCmd := &cobra.Command{
+		Use:   "metrics",
+		Short: "List metrics available in history",
+		RunE:  runStatsMetrics,
+	}
+
+	statsCmd.AddCommand(recordCmd, historyCmd, metricsCmd)
+	return statsCmd
+}
+
+func runStatsRecord(cmd *cobra.Command, args []string) error {
+	every, _ := cmd.Flags().GetDuration("every")
+
+	c, err := getThroneClient()
+	if err != nil {
+		return err
+	}
+	store := stats.DefaultStore()
+
+	record := func() error {
+		s, err := c.GetDashboardStats()
+		if err != nil {
+			return fmt.Errorf("failed to get dashboard stats: %w", err)
+		}
+		snap, err := store.Record(s)
+		if err != nil {
+			return err
+		}
+		fmt.Println(successStyle.Render(ui.Icon.Check+" Recorded snapshot at ") + snap.Time.Local().Format("2006-01-02 15:04:05"))
+		return nil
+	}
+
+	if every <= 0 {
+		return record()
+	}
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	ticker := time.NewTicker(every)
+	defer ticker.Stop()
+
+	fmt.Println(infoStyle.Render(fmt.Sprintf("Recording every %s to %s (Ctrl+C to stop)", every, store.Path)))
+	for {
+		if err := record(); err != nil {
+			// Keep going - throne may be restarting
+			fmt.Fprintln(os.Stderr, errorStyle.Render(ui.WithIcon(ui.Icon.Fail, err.Error())))
+		}
+		select {
+		case <-interrupt:
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func runStatsHistory(cmd *cobra.Command, args []string) error {
+	metricName, _ := cmd.Flags().GetString("metric")
+	since, _ := cmd.Flags().GetString("since")
+	asJSON, _ := cmd.Flags().GetBool("json")
+
+	metric, err := stats.LookupMetric(metricName)
+	if err != nil {
+		return err
+	}
+	window, err := stats.ParseWindow(since)
+	if err != nil {
+		return err
+	}
+
+	snaps, err := stats.DefaultStore().Since(time.Now().Add(-window))
+	if err != nil {
+		return err
+	}
+	points := stats.Series(snaps, metric)
+
+	if asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if points == nil {
+			points = []stats.Point{}
+		}
+		return enc.Encode(points)
+	}
+
+	fmt.Println(titleStyle.Render(ui.WithIcon(ui.Icon.Growth, fmt.Sprintf("%s (last %s)", metric.Name, since))))
+	if len(points) == 0 {
+		fmt.Println(infoStyle.Render("No recorded data. Record snapshots with: reign stats record --every 5m"))
+		return nil
+	}
+
+	for _, p := range points {
+		fmt.Printf("  %s  %s\n",
+			infoStyle.Render(p.Time.Local().Format("2006-01-02 15:04")),
+			formatMetric(p.Value, metric.Unit))
+	}
+
+	first, last := points[0].Value, points[len(points)-1].Value
+	low, high, sum := first, first, 0.0
+	for _, p := range points {
+		low = min(low, p.Value)
+		high = max(high, p.Value)
+		sum += p.Value
+	}
+
+	fmt.Println()
+	fmt.Printf("  %s %d   %s %s   %s %s   %s %s\n",
+		infoStyle.Render("Samples:"), len(points),
+		infoStyle.Render("Min:"), formatMetric(low, metric.Unit),
+		infoStyle.Render("Max:"), formatMetric(high, metric.Unit),
+		infoStyle.Render("Avg:"), formatMetric(sum/float64(len(points)), metric.Unit))
+	if first != 0 {
+		fmt.Printf("  %s %+.1f%% since %s\n",
+			infoStyle.Render("Change:"), (last-first)/first*100,
+			points[0].Time.Local().Format("Jan 2 15:04"))
+	}
+
+	return nil
+}
+
+func runStatsMetrics(cmd *cobra.Command, args []string) error {
+	fmt.Println(titleStyle.Render(ui.WithIcon(ui.Icon.Chart, "Recorded Metrics")))
+	for _, m := range stats.Metrics() {
+		fmt.Printf("  %-20s %s\n", successStyle.Render(m.Name), m.Description)
+	}
+	return nil
+}
+
+func formatMetric(v float64, unit string) string {
+	if unit == "" {
+		return fmt.Sprintf("%.2f", v)
+	}
+	return fmt.Sprintf("%.2f %s", v, unit)
+}
+
+// recordStats appends a snapshot when "record_stats" is enabled in settings
+func recordStats(s *client.DashboardStats) {
+	settings, err := config.LoadSettings()
+	if err != nil || !settings.RecordStats {
+		return
+	}
+	if _, err := stats.DefaultStore().Record(s); err != nil {
+		fmt.Fprintln(os.Stderr, infoStyle.Render("Could not record stats: "+err.Error()))
+	}
+}
+
+// loadTrends builds dashboard chart data from recorded stats history. It
+// returns nil when nothing has been recorded, so dashboards omit the charts.
+func loadTrends() *ui.Trends {
+	now := time.Now()
+	firstDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()).AddDate(0, 0, -6)
+
+	snaps, err := stats.DefaultStore().Since(firstDay)
+	if err != nil || len(snaps) == 0 {
+		return nil
+	}
+	series := func(name string) []stats.Point {
+		m, _ := stats.LookupMetric(name)
+		return stats.Series(snaps, m)
+	}
+
+	trends := &ui.Trends{
+		BurnRate:     stats.Values(series("burn_rate")),
+		AvgLatencyMs: stats.Values(series("avg_latency_ms")),
+		QueueDepth:   stats.Values(series("queue_depth")),
+	}
+
+	// "Today" earnings reset daily, so the day's peak is its total
+	for _, p := range stats.Buckets(series("earnings_today"), firstDay, 24*time.Hour, 7, stats.Max) {
+		trends.EarningsPerDay = append(trends.EarningsPerDay, ui.Bucket{Label: p.Time.Format("Mon"), Value: p.Value})
+	}
+
+	// Requests served is a rolling 24h count; its increases approximate new requests
+	firstHour := now.Truncate(time.Hour).Add(-23 * time.Hour)
+	for _, p := range stats.Buckets(stats.Deltas(series("requests_served")), firstHour, time.Hour, 24, stats.Sum) {
+		trends.RequestsPerHour = append(trends.RequestsPerHour, ui.Bucket{Label: p.Time.Format("15"), Value: p.Value})
+	}
+
+	return trends
+}
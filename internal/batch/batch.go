@@ -0,0 +1,136 @@
+// Package batch runs many chat requests from a JSON Lines file. Results are
+// written as they finish along with a checkpoint, so interrupted runs resume
+// where they left off.
+package batch
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/sovereynai/reign/internal/client"
+)
+
+// Item is one line of a batch input file
+type Item struct {
+	ID          string               `json:"id,omitempty"`    // Defaults to the line number
+	Model       string               `json:"model,omitempty"` // Defaults to the run's model
+	Prompt      string               `json:"prompt,omitempty"`
+	System      string               `json:"system,omitempty"`
+	Messages    []client.ChatMessage `json:"messages,omitempty"` // A full conversation instead of a prompt
+	Temperature *float64             `json:"temperature,omitempty"`
+	MaxTokens   int                  `json:"max_tokens,omitempty"`
+}
+
+// Request builds the chat request for the item
+func (it Item) Request(defaultModel string) client.ChatRequest {
+	req := client.ChatRequest{Model: it.Model}
+	if req.Model == "" {
+		req.Model = defaultModel
+	}
+	if it.System != "" {
+		req.Messages = append(req.Messages, client.ChatMessage{Role: "system", Content: it.System})
+	}
+	req.Messages = append(req.Messages, it.Messages...)
+	if it.Prompt != "" {
+		req.Messages = append(req.Messages, client.ChatMessage{Role: "user", Content: it.Prompt})
+	}
+	if it.Temperature != nil || it.MaxTokens > 0 {
+		req.Options = &client.ChatOptions{Temperature: it.Temperature, MaxTokens: it.MaxTokens}
+	}
+	return req
+}
+
+// ReadItems reads a batch input file. Blank lines are skipped. Lines that
+// aren't valid items and duplicate IDs are errors, as IDs are how results are
+// matched to items when resuming.
+func ReadItems(path string) ([]Item, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open batch input: %w", err)
+	}
+	defer f.Close()
+
+	var items []Item
+	seen := make(map[string]int)
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		var it Item
+		if err := json.Unmarshal([]byte(text), &it); err != nil {
+			return nil, fmt.Errorf("%s:%d: invalid item: %w", path, line, err)
+		}
+		if it.Prompt == "" && len(it.Messages) == 0 {
+			return nil, fmt.Errorf("%s:%d: item has no prompt or messages", path, line)
+		}
+		if it.ID == "" {
+			it.ID = strconv.Itoa(line)
+		}
+		if prev, ok := seen[it.ID]; ok {
+			return nil, fmt.Errorf("%s:%d: duplicate id %q (also on line %d)", path, line, it.ID, prev)
+		}
+		seen[it.ID] = line
+		items = append(items, it)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read batch input: %w", err)
+	}
+
+	return items, nil
+}
+
+// Result is one line of a batch output file
+type Result struct {
+	ID        string  `json:"id"`
+	Model     string  `json:"model"`
+	Response  string  `json:"response,omitempty"`
+	Error     string  `json:"error,omitempty"`
+	LatencyMs int64   `json:"latency_ms"`
+	Cost      float64 `json:"cost,omitempty"`
+	NodeID    string  `json:"node_id,omitempty"`
+	Attempts  int     `json:"attempts"`
+}
+
+// Summary describes the results of a run
+type Summary struct {
+	Total        int     `json:"total"`
+	Succeeded    int     `json:"succeeded"`
+	Failed       int     `json:"failed"`
+	Cost         float64 `json:"cost"`
+	P50LatencyMs int64   `json:"p50_latency_ms"`
+	P95LatencyMs int64   `json:"p95_latency_ms"`
+	P99LatencyMs int64   `json:"p99_latency_ms"`
+}
+
+// Summarize totals results. Latency percentiles cover successful requests.
+func Summarize(results []Result) Summary {
+	s := Summary{Total: len(results)}
+	var latencies []int64
+	for _, r := range results {
+		s.Cost += r.Cost
+		if r.Error != "" {
+			s.Failed++
+			continue
+		}
+		s.Succeeded++
+		latencies = append(latencies, r.LatencyMs)
+	}
+	if len(latencies) > 0 {
+		slices.Sort(latencies)
+		pct := func(p float64) int64 {
+			return latencies[int(math.Ceil(p*float64(len(latencies))))-1]
+		}
+		s.P50LatencyMs, s.P95LatencyMs, s.P99LatencyMs = pct(0.50), pct(0.95), pct(0.99)
+	}
+	return s
+}
@@ -0,0 +1,422 @@
+package batch
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sovereynai/reign/internal/client"
+)
+
+// fakeSend answers every request, failing prompts listed in fail
+func fakeSend(fail ...string) (func(ctx context.Context, req client.ChatRequest) (*client.ChatResponse, error), func() []string) {
+	var mu sync.Mutex
+	var sent []string
+	send := func(ctx context.Context, req client.ChatRequest) (*client.ChatResponse, error) {
+		prompt := req.Messages[len(req.Messages)-1].Content
+		mu.Lock()
+		sent = append(sent, prompt)
+		mu.Unlock()
+		for _, f := range fail {
+			if prompt == f {
+				return &client.ChatResponse{Success: false, Error: "model not loaded", Cost: 0.001}, nil
+			}
+		}
+		return &client.ChatResponse{Success: true, Message: client.ChatMessage{Role: "assistant", Content: "re: " + prompt}, Cost: 0.01, LatencyMs: 5}, nil
+	}
+	calls := func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), sent...)
+	}
+	return send, calls
+}
+
+func testItems(ids ...string) []Item {
+	items := make([]Item, len(ids))
+	for i, id := range ids {
+		items[i] = Item{ID: id, Prompt: "prompt " + id}
+	}
+	return items
+}
+
+// readResultIDs returns the IDs of the results in an output file, in order
+func readResultIDs(t *testing.T, path string) []string {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var ids []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var r Result
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			t.Fatalf("invalid result line %q: %v", scanner.Text(), err)
+		}
+		ids = append(ids, r.ID)
+	}
+	return ids
+}
+
+func TestOpenOutput(t *testing.T) {
+	tests := []struct {
+		name       string
+		output     string // Existing output file content, if any
+		checkpoint string // Existing checkpoint content, if any
+		hash       string
+		restart    bool
+		wantErr    string
+		wantDone   []string
+	}{
+		{name: "new output", hash: "abc"},
+		{name: "existing output without checkpoint", output: "{}\n", hash: "abc", wantErr: "already exists"},
+		{name: "existing output with restart", output: "{}\n", hash: "abc", restart: true},
+		{
+			name:       "resume same input",
+			checkpoint: `{"input":"abc"}` + "\n" + `{"id":"1","ok":true}` + "\n" + `{"id":"2"}` + "\n",
+			hash:       "abc",
+			wantDone:   []string{"1"},
+		},
+		{
+			name:       "wrong input hash",
+			checkpoint: `{"input":"abc"}` + "\n" + `{"id":"1","ok":true}` + "\n",
+			hash:       "def",
+			wantErr:    "different input file",
+		},
+		{
+			name:       "wrong input hash with restart",
+			checkpoint: `{"input":"abc"}` + "\n" + `{"id":"1","ok":true}` + "\n",
+			hash:       "def",
+			restart:    true,
+		},
+		{
+			name:       "partial last line",
+			checkpoint: `{"input":"abc"}` + "\n" + `{"id":"1","ok":true}` + "\n" + `{"id":"2","o`,
+			hash:       "abc",
+			wantDone:   []string{"1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "out.jsonl")
+			if tt.output != "" {
+				if err := os.WriteFile(path, []byte(tt.output), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if tt.checkpoint != "" {
+				if err := os.WriteFile(CheckpointPath(path), []byte(tt.checkpoint), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			out, err := OpenOutput(path, tt.hash, tt.restart)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("OpenOutput() error = %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("OpenOutput() error = %v", err)
+			}
+			defer out.Close()
+
+			for _, id := range []string{"1", "2", "3"} {
+				want := false
+				for _, done := range tt.wantDone {
+					want = want || done == id
+				}
+				if got := out.Succeeded(id); got != want {
+					t.Errorf("Succeeded(%q) = %v, want %v", id, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestRunResume(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "out.jsonl")
+	items := testItems("a", "b", "c")
+
+	// The first run fails one item, which is left in the checkpoint
+	send, _ := fakeSend("prompt b")
+	out, err := OpenOutput(path, "hash", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := (&Runner{Model: "m", Send: send}).Run(context.Background(), items, out); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	results, err := out.Finish(items)
+	if err != nil {
+		t.Fatalf("Finish() error = %v", err)
+	}
+	if s := Summarize(results); s.Succeeded != 2 || s.Failed != 1 {
+		t.Fatalf("first run: %d succeeded and %d failed, want 2 and 1", s.Succeeded, s.Failed)
+	}
+	if _, err := os.Stat(CheckpointPath(path)); err != nil {
+		t.Fatalf("checkpoint removed after an incomplete run: %v", err)
+	}
+
+	// Running again only retries the failed item, and completes the run
+	send, calls := fakeSend()
+	out, err = OpenOutput(path, "hash", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := (&Runner{Model: "m", Send: send}).Run(context.Background(), items, out); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if got := calls(); len(got) != 1 || got[0] != "prompt b" {
+		t.Errorf("resumed run sent %q, want only the failed item", got)
+	}
+	results, err = out.Finish(items)
+	if err != nil {
+		t.Fatalf("Finish() error = %v", err)
+	}
+	if s := Summarize(results); s.Succeeded != 3 || s.Failed != 0 {
+		t.Errorf("second run: %d succeeded and %d failed, want 3 and 0", s.Succeeded, s.Failed)
+	}
+	if got := readResultIDs(t, path); strings.Join(got, ",") != "a,b,c" {
+		t.Errorf("output has results %q, want one each for a, b and c", got)
+	}
+	if _, err := os.Stat(CheckpointPath(path)); !os.IsNotExist(err) {
+		t.Errorf("checkpoint left after every item succeeded (stat error %v)", err)
+	}
+}
+
+func TestFinishInputOrder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.jsonl")
+	items := testItems("1", "2", "3", "4", "5")
+	out, err := OpenOutput(path, "hash", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Results are written as they finish: in reverse, with an earlier
+	// failed attempt at item 3
+	for _, r := range []Result{
+		{ID: "3", Error: "model not loaded"},
+		{ID: "5"}, {ID: "4"}, {ID: "3", Response: "ok"}, {ID: "2"}, {ID: "1"},
+	} {
+		if err := out.Write(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	results, err := out.Finish(items)
+	if err != nil {
+		t.Fatalf("Finish() error = %v", err)
+	}
+
+	if got := readResultIDs(t, path); strings.Join(got, ",") != "1,2,3,4,5" {
+		t.Errorf("output order = %q, want input order", got)
+	}
+	if len(results) != 5 || results[2].Response != "ok" {
+		t.Errorf("Finish() kept %+v for item 3, want the latest result", results[2])
+	}
+}
+
+func TestRunStop(t *testing.T) {
+	tests := []struct {
+		name      string
+		check     func(req client.ChatRequest) (func(), error)
+		cancelOn  string // Prompt whose Send cancels the run
+		wantErr   error
+		wantIDs   []string // Items with a result, in input order
+		wantFails int
+	}{
+		{
+			name: "check fails one item",
+			check: func(req client.ChatRequest) (func(), error) {
+				if req.Messages[0].Content == "prompt 2" {
+					return nil, errors.New("too expensive")
+				}
+				return func() {}, nil
+			},
+			wantIDs:   []string{"1", "2", "3"},
+			wantFails: 1,
+		},
+		{
+			name: "check stops the run",
+			check: func(req client.ChatRequest) (func(), error) {
+				if req.Messages[0].Content == "prompt 2" {
+					return nil, fmt.Errorf("%w: daily budget used up", ErrStop)
+				}
+				return func() {}, nil
+			},
+			wantErr: ErrStop,
+			wantIDs: []string{"1"},
+		},
+		{
+			name:     "cancelled",
+			cancelOn: "prompt 2",
+			wantErr:  context.Canceled,
+			wantIDs:  []string{"1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "out.jsonl")
+			items := testItems("1", "2", "3")
+			out, err := OpenOutput(path, "hash", false)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			send, _ := fakeSend()
+			runner := &Runner{
+				Model:       "m",
+				Concurrency: 1,
+				Check:       tt.check,
+				Send: func(ctx context.Context, req client.ChatRequest) (*client.ChatResponse, error) {
+					if req.Messages[0].Content == tt.cancelOn {
+						cancel()
+						return nil, ctx.Err()
+					}
+					return send(ctx, req)
+				},
+			}
+
+			err = runner.Run(ctx, items, out)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Run() error = %v, want %v", err, tt.wantErr)
+			}
+			results, err := out.Finish(items)
+			if err != nil {
+				t.Fatalf("Finish() error = %v", err)
+			}
+			var ids []string
+			for _, r := range results {
+				ids = append(ids, r.ID)
+			}
+			if strings.Join(ids, ",") != strings.Join(tt.wantIDs, ",") {
+				t.Errorf("results for %q, want %q", ids, tt.wantIDs)
+			}
+			if s := Summarize(results); s.Failed != tt.wantFails {
+				t.Errorf("%d items failed, want %d", s.Failed, tt.wantFails)
+			}
+		})
+	}
+}
+
+func TestRunReleasesChecks(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.jsonl")
+	items := testItems("1", "2", "3", "4", "5", "6")
+	out, err := OpenOutput(path, "hash", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer out.Close()
+
+	var mu sync.Mutex
+	inFlight, reserved, released := 0, 0, 0
+	send, _ := fakeSend()
+	runner := &Runner{
+		Model:       "m",
+		Concurrency: 3,
+		Check: func(req client.ChatRequest) (func(), error) {
+			mu.Lock()
+			defer mu.Unlock()
+			reserved++
+			inFlight++
+			return func() {
+				mu.Lock()
+				defer mu.Unlock()
+				released++
+				inFlight--
+			}, nil
+		},
+		Send: send,
+	}
+	if err := runner.Run(context.Background(), items, out); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if reserved != 6 || released != 6 || inFlight != 0 {
+		t.Errorf("%d checks passed and %d released, want all 6 of each", reserved, released)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	latencies := func(from, to int64) []Result {
+		var results []Result
+		for ms := from; ms <= to; ms++ {
+			results = append(results, Result{LatencyMs: ms, Cost: 0.5})
+		}
+		return results
+	}
+	tests := []struct {
+		name    string
+		results []Result
+		want    Summary
+	}{
+		{name: "no results", want: Summary{}},
+		{
+			name:    "one result",
+			results: []Result{{LatencyMs: 40, Cost: 0.25}},
+			want:    Summary{Total: 1, Succeeded: 1, Cost: 0.25, P50LatencyMs: 40, P95LatencyMs: 40, P99LatencyMs: 40},
+		},
+		{
+			name:    "ten results",
+			results: latencies(1, 10),
+			want:    Summary{Total: 10, Succeeded: 10, Cost: 5, P50LatencyMs: 5, P95LatencyMs: 10, P99LatencyMs: 10},
+		},
+		{
+			name:    "hundred results",
+			results: latencies(1, 100),
+			want:    Summary{Total: 100, Succeeded: 100, Cost: 50, P50LatencyMs: 50, P95LatencyMs: 95, P99LatencyMs: 99},
+		},
+		{
+			name: "failures count cost but not latency",
+			results: []Result{
+				{LatencyMs: 10, Cost: 1},
+				{LatencyMs: 9000, Error: "timeout", Cost: 0.5},
+				{LatencyMs: 30, Cost: 1},
+			},
+			want: Summary{Total: 3, Succeeded: 2, Failed: 1, Cost: 2.5, P50LatencyMs: 10, P95LatencyMs: 30, P99LatencyMs: 30},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Summarize(tt.results); got != tt.want {
+				t.Errorf("Summarize() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryWait(t *testing.T) {
+	tests := []struct {
+		name    string
+		attempt int
+		err     error
+		want    time.Duration
+	}{
+		{name: "first retry", attempt: 0, err: errors.New("boom"), want: time.Second},
+		{name: "doubles", attempt: 3, err: errors.New("boom"), want: 8 * time.Second},
+		{name: "capped", attempt: 10, err: errors.New("boom"), want: time.Minute},
+		{name: "retry after", attempt: 0, err: &client.RateLimitError{RetryAfter: 20 * time.Second}, want: 20 * time.Second},
+		{name: "shorter retry after", attempt: 2, err: &client.RateLimitError{RetryAfter: time.Second}, want: 4 * time.Second},
+		{name: "retry after capped", attempt: 0, err: &client.RateLimitError{RetryAfter: time.Hour}, want: time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := retryWait(tt.attempt, tt.err); got != tt.want {
+				t.Errorf("retryWait(%d, %v) = %v, want %v", tt.attempt, tt.err, got, tt.want)
+			}
+		})
+	}
+}
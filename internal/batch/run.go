@@ -0,0 +1,406 @@
+package batch
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/sovereynai/reign/internal/client"
+)
+
+// ErrStop is returned (wrapped) by a Runner's Check to stop the whole run
+// instead of failing one item
+var ErrStop = errors.New("batch stopped")
+
+// Retry waits grow from one second, but follow throne's Retry-After
+const (
+	firstRetryWait = time.Second
+	maxRetryWait   = time.Minute
+)
+
+// CheckpointPath is where the checkpoint of an output file is kept
+func CheckpointPath(output string) string {
+	return output + ".checkpoint"
+}
+
+// HashFile identifies an input file's content, so a checkpoint isn't
+// resumed against a different input
+func HashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open batch input: %w", err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("failed to read batch input: %w", err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// checkpointLine is a line of a checkpoint file: the input hash first, then
+// one line per finished item
+type checkpointLine struct {
+	Input string `json:"input,omitempty"`
+	ID    string `json:"id,omitempty"`
+	OK    bool   `json:"ok,omitempty"`
+}
+
+// Output is a batch output file with its checkpoint
+type Output struct {
+	Path string
+
+	mu        sync.Mutex
+	succeeded map[string]bool // Finished items and whether they succeeded
+	out, ckpt *os.File
+}
+
+// OpenOutput opens the output file for a run over an input with the given
+// hash. A checkpoint left by an interrupted run of the same input is
+// resumed. With restart, earlier output and checkpoints are discarded.
+func OpenOutput(path, inputHash string, restart bool) (*Output, error) {
+	o := &Output{Path: path, succeeded: make(map[string]bool)}
+	ckptPath := CheckpointPath(path)
+
+	if restart {
+		for _, p := range []string{path, ckptPath} {
+			if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+				return nil, fmt.Errorf("failed to remove %s: %w", p, err)
+			}
+		}
+	}
+
+	resuming, err := o.loadCheckpoint(ckptPath, inputHash)
+	if err != nil {
+		return nil, err
+	}
+	if !resuming {
+		if _, err := os.Stat(path); err == nil {
+			return nil, fmt.Errorf("%s already exists; use --restart to overwrite it", path)
+		}
+	}
+
+	o.out, err = os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open batch output: %w", err)
+	}
+	o.ckpt, err = os.OpenFile(ckptPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		o.out.Close()
+		return nil, fmt.Errorf("failed to open checkpoint: %w", err)
+	}
+	if !resuming {
+		if err := o.appendCheckpoint(checkpointLine{Input: inputHash}); err != nil {
+			o.Close()
+			return nil, err
+		}
+	}
+	return o, nil
+}
+
+// loadCheckpoint reads an existing checkpoint, reporting whether there was one
+func (o *Output) loadCheckpoint(path, inputHash string) (bool, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to open checkpoint: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for first := true; scanner.Scan(); first = false {
+		var line checkpointLine
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			continue // A partial write from an interrupted run
+		}
+		if first && line.Input != inputHash {
+			return false, fmt.Errorf("checkpoint %s is from a different input file; use --restart to start over", path)
+		}
+		if line.ID != "" {
+			o.succeeded[line.ID] = line.OK
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return false, fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+	return true, nil
+}
+
+func (o *Output) appendCheckpoint(line checkpointLine) error {
+	data, err := json.Marshal(line)
+	if err != nil {
+		return fmt.Errorf("failed to encode checkpoint: %w", err)
+	}
+	if _, err := o.ckpt.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	return nil
+}
+
+// Succeeded reports whether an item already has a successful result
+func (o *Output) Succeeded(id string) bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.succeeded[id]
+}
+
+// Finished returns how many items have a result, and how many of those failed
+func (o *Output) Finished() (finished, failed int) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for _, ok := range o.succeeded {
+		finished++
+		if !ok {
+			failed++
+		}
+	}
+	return finished, failed
+}
+
+// Write appends a result to the output, then marks it in the checkpoint
+func (o *Output) Write(r Result) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	data, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("failed to encode result: %w", err)
+	}
+	if _, err := o.out.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write batch output: %w", err)
+	}
+	if err := o.appendCheckpoint(checkpointLine{ID: r.ID, OK: r.Error == ""}); err != nil {
+		return err
+	}
+	o.succeeded[r.ID] = r.Error == ""
+	return nil
+}
+
+// Close closes the output and checkpoint files
+func (o *Output) Close() error {
+	err := o.out.Close()
+	if cerr := o.ckpt.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// Finish closes the output and rewrites it with the latest result of each
+// item, in input order, dropping results of earlier attempts. Once every item
+// has succeeded the checkpoint is removed. It returns the results.
+func (o *Output) Finish(items []Item) ([]Result, error) {
+	if err := o.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close batch output: %w", err)
+	}
+
+	f, err := os.Open(o.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open batch output: %w", err)
+	}
+	latest := make(map[string]Result)
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var r Result
+		if err := json.Unmarshal(scanner.Bytes(), &r); err == nil && r.ID != "" {
+			latest[r.ID] = r
+		}
+	}
+	f.Close()
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read batch output: %w", err)
+	}
+
+	var results []Result
+	complete := true
+	tmp, err := os.CreateTemp(filepath.Dir(o.Path), ".batch-*.jsonl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to rewrite batch output: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	w := bufio.NewWriter(tmp)
+	enc := json.NewEncoder(w)
+	for _, it := range items {
+		r, ok := latest[it.ID]
+		if !ok || r.Error != "" {
+			complete = false
+		}
+		if !ok {
+			continue
+		}
+		results = append(results, r)
+		if err := enc.Encode(r); err != nil {
+			tmp.Close()
+			return nil, fmt.Errorf("failed to rewrite batch output: %w", err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		tmp.Close()
+		return nil, fmt.Errorf("failed to rewrite batch output: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return nil, fmt.Errorf("failed to rewrite batch output: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return nil, fmt.Errorf("failed to rewrite batch output: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), o.Path); err != nil {
+		return nil, fmt.Errorf("failed to rewrite batch output: %w", err)
+	}
+
+	if complete {
+		if err := os.Remove(CheckpointPath(o.Path)); err != nil && !os.IsNotExist(err) {
+			return results, fmt.Errorf("failed to remove checkpoint: %w", err)
+		}
+	}
+	return results, nil
+}
+
+// Runner sends batch items to throne
+type Runner struct {
+	Model       string // For items that don't name one
+	Concurrency int
+	Retries     int // Further attempts for items that fail
+
+	Send func(ctx context.Context, req client.ChatRequest) (*client.ChatResponse, error)
+
+	// Check can refuse an item before it is sent, e.g. when it is over
+	// budget. The item fails with its error, unless it wraps ErrStop. When
+	// the item is allowed, release is called once it is finished, so checks
+	// can account for the items in flight.
+	Check func(req client.ChatRequest) (release func(), err error)
+
+	// OnResult is called after each result is written, one at a time
+	OnResult func(Result)
+}
+
+// Run sends every item that hasn't already succeeded and writes the results
+// to out. It stops early when ctx is cancelled or Check returns ErrStop,
+// leaving the unfinished items for a later resume, and returns why.
+func (r *Runner) Run(ctx context.Context, items []Item, out *Output) error {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	todo := make(chan Item)
+	var wg sync.WaitGroup
+	var mu sync.Mutex // Serializes OnResult
+	for range max(1, r.Concurrency) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for it := range todo {
+				res, err := r.process(ctx, it)
+				if err != nil {
+					cancel(err)
+					continue
+				}
+				mu.Lock()
+				if err := out.Write(res); err != nil {
+					cancel(err)
+				} else if r.OnResult != nil {
+					r.OnResult(res)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+feed:
+	for _, it := range items {
+		if out.Succeeded(it.ID) {
+			continue
+		}
+		select {
+		case todo <- it:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(todo)
+	wg.Wait()
+
+	return context.Cause(ctx)
+}
+
+// process sends an item, retrying failures. It returns an error only when the
+// run is stopping and the item should be left for a resume.
+func (r *Runner) process(ctx context.Context, it Item) (Result, error) {
+	req := it.Request(r.Model)
+	res := Result{ID: it.ID, Model: req.Model}
+
+	if r.Check != nil {
+		release, err := r.Check(req)
+		if err != nil {
+			if errors.Is(err, ErrStop) {
+				return res, err
+			}
+			res.Error = err.Error()
+			return res, nil
+		}
+		defer release()
+	}
+
+	for attempt := 0; ; attempt++ {
+		res.Attempts = attempt + 1
+		start := time.Now()
+		resp, err := r.Send(ctx, req)
+		if ctx.Err() != nil {
+			return res, context.Cause(ctx)
+		}
+		if resp != nil {
+			res.Cost += resp.Cost
+		}
+		if err == nil && !resp.Success {
+			err = errors.New("inference returned success=false")
+			if resp.Error != "" {
+				err = errors.New(resp.Error)
+			}
+		}
+
+		res.LatencyMs = time.Since(start).Milliseconds()
+		if err == nil {
+			res.Response = resp.Message.Content
+			res.NodeID = resp.NodeID
+			res.Error = ""
+			if resp.LatencyMs > 0 {
+				res.LatencyMs = resp.LatencyMs
+			}
+			return res, nil
+		}
+		res.Error = err.Error()
+		if attempt >= r.Retries {
+			return res, nil
+		}
+
+		timer := time.NewTimer(retryWait(attempt, err))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return res, context.Cause(ctx)
+		}
+	}
+}
+
+// retryWait doubles the wait with each attempt, or waits as long as throne
+// asked when rate limited
+func retryWait(attempt int, err error) time.Duration {
+	wait := min(maxRetryWait, firstRetryWait<<attempt)
+	var limited *client.RateLimitError
+	if errors.As(err, &limited) && limited.RetryAfter > wait {
+		wait = min(maxRetryWait, limited.RetryAfter)
+	}
+	return wait
+}
@@ -1,6 +1,7 @@
 package bootstrap
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
@@ -8,6 +9,10 @@ import (
 	"runtime"
 	"strings"
 	"time"
+
+	"github.com/sovereynai/reign/internal/config"
+	"github.com/sovereynai/reign/internal/tracing"
+	"github.com/sovereynai/reign/internal/ui"
 )
 
 // Setup performs first-run initialization
@@ -17,16 +22,16 @@ func Setup() error {
 	}
 
 	fmt.Println()
-	fmt.Println("🚀 Welcome to Sovereyn!")
+	fmt.Println(ui.WithIcon(ui.Icon.Rocket, "Welcome to Sovereyn!"))
 	fmt.Println()
-	fmt.Println("⏳ First-time setup (this will only happen once)...")
+	fmt.Println(ui.WithIcon(ui.Icon.Hourglass, "First-time setup (this will only happen once)..."))
 	fmt.Println()
 
 	// Check system requirements
 	if err := checkSystemRequirements(); err != nil {
 		return fmt.Errorf("system requirements not met: %w", err)
 	}
-	fmt.Println("   ✅ System requirements met")
+	fmt.Println("   " + ui.WithIcon(ui.Icon.OK, "System requirements met"))
 
 	// Ensure Ollama
 	if err := ensureOllama(); err != nil {
@@ -35,27 +40,27 @@ func Setup() error {
 
 	// Check if throne is installed (optional - not required if user points to remote daemon)
 	if isThroneInstalled() {
-		fmt.Println("   ✅ Throne daemon installed")
+		fmt.Println("   " + ui.WithIcon(ui.Icon.OK, "Throne daemon installed"))
 	} else {
-		fmt.Println("   ℹ️  Throne binary not in PATH (will use THRONE_URL if set)")
+		fmt.Println("   " + ui.WithIcon(ui.Icon.Info, "Throne binary not in PATH (will use THRONE_URL if set)"))
 	}
 
 	// Pull default model
 	if err := ensureDefaultModel("llama3.2:3b"); err != nil {
 		// Not fatal - user can pull models later
-		fmt.Printf("   ⚠️  Could not pull default model: %v\n", err)
+		fmt.Println("   " + ui.WithIcon(ui.Icon.Alert, fmt.Sprintf("Could not pull default model: %v", err)))
 		fmt.Println("      You can pull models later with: ollama pull llama3.2:3b")
 	}
 
 	fmt.Println()
-	fmt.Println("✨ Setup complete!")
+	fmt.Println(ui.WithIcon(ui.Icon.Sparkles, "Setup complete!"))
 	fmt.Println()
 
 	// Mark as complete
 	markSetupComplete()
 
 	// Offer to start throne
-	fmt.Println("📋 Next steps:")
+	fmt.Println(ui.WithIcon(ui.Icon.Clipboard, "Next steps:"))
 	fmt.Println("   1. Start throne daemon: throne serve &")
 	fmt.Println("   2. Run your first inference: reign chat \"Hello world\"")
 	fmt.Println()
@@ -65,13 +70,17 @@ func Setup() error {
 
 // EnsureThroneRunning checks if throne is running and offers to start it
 func EnsureThroneRunning() error {
+	ctx, span := tracing.Start(context.Background(), "bootstrap.EnsureThroneRunning")
+	defer span.End()
+
 	// Check if throne is responding
-	if isThroneResponding() {
+	if isThroneResponding(ctx) {
 		return nil
 	}
+	span.SetError(fmt.Errorf("throne daemon not running"))
 
 	fmt.Println()
-	fmt.Println("⚠️  Throne daemon not running!")
+	fmt.Println(ui.WithIcon(ui.Icon.Alert, "Throne daemon not running!"))
 	fmt.Println()
 	fmt.Println("Start it with:")
 	fmt.Println("   throne serve &")
@@ -98,11 +107,7 @@ func markSetupComplete() {
 }
 
 func getSovereignHome() string {
-	if home := os.Getenv("SOVEREYN_HOME"); home != "" {
-		return home
-	}
-	home, _ := os.UserHomeDir()
-	return filepath.Join(home, ".sovereyn")
+	return config.Home()
 }
 
 func checkSystemRequirements() error {
@@ -125,26 +130,26 @@ func checkSystemRequirements() error {
 func ensureOllama() error {
 	// Check if already installed
 	if isOllamaInstalled() {
-		fmt.Println("   ✅ Ollama already installed")
+		fmt.Println("   " + ui.WithIcon(ui.Icon.OK, "Ollama already installed"))
 
 		// Make sure it's running
 		if !isOllamaRunning() {
-			fmt.Println("   ⏳ Starting Ollama service...")
+			fmt.Println("   " + ui.WithIcon(ui.Icon.Hourglass, "Starting Ollama service..."))
 			if err := startOllama(); err != nil {
-				fmt.Printf("   ⚠️  Could not start Ollama automatically: %v\n", err)
+				fmt.Println("   " + ui.WithIcon(ui.Icon.Alert, fmt.Sprintf("Could not start Ollama automatically: %v", err)))
 				fmt.Println("      Please run: ollama serve &")
 			} else {
 				time.Sleep(2 * time.Second) // Wait for startup
-				fmt.Println("   ✅ Ollama service started")
+				fmt.Println("   " + ui.WithIcon(ui.Icon.OK, "Ollama service started"))
 			}
 		} else {
-			fmt.Println("   ✅ Ollama service running")
+			fmt.Println("   " + ui.WithIcon(ui.Icon.OK, "Ollama service running"))
 		}
 		return nil
 	}
 
 	// Not installed - install it
-	fmt.Println("   ⏳ Installing Ollama...")
+	fmt.Println("   " + ui.WithIcon(ui.Icon.Hourglass, "Installing Ollama..."))
 	return installOllama()
 }
 
@@ -184,20 +189,20 @@ func installOllama() error {
 func installOllamaMacOS() error {
 	// Check for Homebrew
 	if _, err := exec.LookPath("brew"); err == nil {
-		fmt.Println("      • Using Homebrew...")
+		fmt.Println("      " + ui.WithIcon(ui.Icon.Bullet, "Using Homebrew..."))
 		cmd := exec.Command("brew", "install", "ollama")
 		cmd.Stdout = os.Stdout
 		cmd.Stderr = os.Stderr
 		if err := cmd.Run(); err != nil {
 			return fmt.Errorf("brew install failed: %w", err)
 		}
-		fmt.Println("   ✅ Ollama installed via Homebrew")
+		fmt.Println("   " + ui.WithIcon(ui.Icon.OK, "Ollama installed via Homebrew"))
 		return startOllama()
 	}
 
 	// No homebrew - give manual instructions
 	fmt.Println()
-	fmt.Println("   ⚠️  Homebrew not found")
+	fmt.Println("   " + ui.WithIcon(ui.Icon.Alert, "Homebrew not found"))
 	fmt.Println()
 	fmt.Println("   Install Ollama manually:")
 	fmt.Println("      1. Visit: https://ollama.ai/download")
@@ -208,7 +213,7 @@ func installOllamaMacOS() error {
 }
 
 func installOllamaLinux() error {
-	fmt.Println("      • Downloading installer...")
+	fmt.Println("      " + ui.WithIcon(ui.Icon.Bullet, "Downloading installer..."))
 
 	// Use the official install script
 	cmd := exec.Command("curl", "-fsSL", "https://ollama.ai/install.sh")
@@ -225,13 +230,13 @@ func installOllamaLinux() error {
 		return fmt.Errorf("installation failed: %w", err)
 	}
 
-	fmt.Println("   ✅ Ollama installed")
+	fmt.Println("   " + ui.WithIcon(ui.Icon.OK, "Ollama installed"))
 	return startOllama()
 }
 
 func installOllamaWindows() error {
 	fmt.Println()
-	fmt.Println("   ⚠️  Automatic installation not available on Windows")
+	fmt.Println("   " + ui.WithIcon(ui.Icon.Alert, "Automatic installation not available on Windows"))
 	fmt.Println()
 	fmt.Println("   Install Ollama manually:")
 	fmt.Println("      1. Visit: https://ollama.ai/download")
@@ -247,12 +252,12 @@ func ensureDefaultModel(model string) error {
 	cmd := exec.Command("ollama", "list")
 	output, err := cmd.Output()
 	if err == nil && strings.Contains(string(output), model) {
-		fmt.Printf("   ✅ Model '%s' already available\n", model)
+		fmt.Println("   " + ui.WithIcon(ui.Icon.OK, fmt.Sprintf("Model '%s' already available", model)))
 		return nil
 	}
 
 	// Pull the model
-	fmt.Printf("   ⏳ Pulling default model (%s)...\n", model)
+	fmt.Println("   " + ui.WithIcon(ui.Icon.Hourglass, fmt.Sprintf("Pulling default model (%s)...", model)))
 	fmt.Println("      (This may take 2-5 minutes for first download)")
 	fmt.Println()
 
@@ -265,7 +270,7 @@ func ensureDefaultModel(model string) error {
 	}
 
 	fmt.Println()
-	fmt.Printf("   ✅ Model '%s' ready!\n", model)
+	fmt.Println("   " + ui.WithIcon(ui.Icon.OK, fmt.Sprintf("Model '%s' ready!", model)))
 	return nil
 }
 
@@ -274,31 +279,37 @@ func isThroneInstalled() bool {
 	return err == nil
 }
 
-func isThroneResponding() bool {
+func isThroneResponding(ctx context.Context) bool {
 	// Check if THRONE_URL is explicitly set
 	if throneURL := os.Getenv("THRONE_URL"); throneURL != "" {
-		cmd := exec.Command("curl", "-s", "-o", "/dev/null", "-w", "%{http_code}",
-			throneURL+"/healthz")
-		output, err := cmd.Output()
-		if err == nil && string(output) == "200" {
-			return true
-		}
-		return false
+		return curlHealthy(ctx, throneURL+"/healthz")
 	}
 
 	// Try common ports
 	ports := []string{"8080", "8081", "8082", "8083", "8090", "8091"}
 	for _, port := range ports {
-		cmd := exec.Command("curl", "-s", "-o", "/dev/null", "-w", "%{http_code}",
-			fmt.Sprintf("http://localhost:%s/healthz", port))
-		output, err := cmd.Output()
-		if err == nil && string(output) == "200" {
+		if curlHealthy(ctx, fmt.Sprintf("http://localhost:%s/healthz", port)) {
 			return true
 		}
 	}
 	return false
 }
 
+// curlHealthy checks a health endpoint with curl
+func curlHealthy(ctx context.Context, url string) bool {
+	_, span := tracing.Start(ctx, "bootstrap.probe")
+	defer span.End()
+	span.SetAttr("url.full", url)
+
+	cmd := exec.Command("curl", "-s", "-o", "/dev/null", "-w", "%{http_code}", url)
+	if tp := span.Traceparent(); tp != "" {
+		cmd.Args = append(cmd.Args, "-H", "traceparent: "+tp)
+	}
+	output, err := cmd.Output()
+	span.SetAttr("http.response.status_code", string(output))
+	return err == nil && string(output) == "200"
+}
+
 // GetModelSize returns estimated model size for display
 func GetModelSize(model string) string {
 	sizes := map[string]string{
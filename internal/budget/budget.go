@@ -0,0 +1,184 @@
+// Package budget enforces local credit spending limits before requests are
+// sent to throne.
+package budget
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/sovereynai/reign/internal/config"
+)
+
+// Budget scopes
+const (
+	ScopeDaily      = "daily"
+	ScopeModel      = "model"
+	ScopeProject    = "project"
+	ScopePerRequest = "per_request"
+)
+
+// defaultWarnAt is the share of a budget at which warnings start
+const defaultWarnAt = 0.8
+
+// Usage is spending against one budget
+type Usage struct {
+	Scope string  `json:"scope"`
+	Name  string  `json:"name,omitempty"` // Model or project
+	Limit float64 `json:"limit"`
+	Spent float64 `json:"spent"` // Today, or the request's estimate for per-request budgets
+}
+
+// Fraction is the share of the budget spent
+func (u Usage) Fraction() float64 {
+	if u.Limit <= 0 {
+		return 0
+	}
+	return u.Spent / u.Limit
+}
+
+// Label describes the budget, e.g. "Model llama3.2:3b"
+func (u Usage) Label() string {
+	switch u.Scope {
+	case ScopeDaily:
+		return "Daily"
+	case ScopePerRequest:
+		return "Per request"
+	}
+	return strings.ToUpper(u.Scope[:1]) + u.Scope[1:] + " " + u.Name
+}
+
+// ExceededError is returned by Check when a request would go over budget
+type ExceededError struct {
+	Estimate float64
+	Exceeded []Usage
+}
+
+func (e *ExceededError) Error() string {
+	var parts []string
+	for _, u := range e.Exceeded {
+		if u.Scope == ScopePerRequest {
+			parts = append(parts, fmt.Sprintf("estimated cost %.4f is over the per-request budget of %.4f", u.Spent, u.Limit))
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%s budget of %.4f credits/day would be exceeded (%.4f spent today, ~%.4f for this request)",
+			strings.ToLower(u.Label()), u.Limit, u.Spent, e.Estimate))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// Today returns the start of the current local day, when daily budgets reset
+func Today(now time.Time) time.Time {
+	y, m, d := now.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, now.Location())
+}
+
+// CurrentProject names the project requests are charged to: $REIGN_PROJECT,
+// or else the name of the working directory
+func CurrentProject() string {
+	if p := os.Getenv("REIGN_PROJECT"); p != "" {
+		return p
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+	return filepath.Base(wd)
+}
+
+// Status returns today's spending against every configured budget. The
+// per-request budget is left out, as it doesn't accumulate.
+func Status(s config.BudgetSettings, today []Spend) []Usage {
+	var out []Usage
+	if s.Daily > 0 {
+		out = append(out, Usage{Scope: ScopeDaily, Limit: s.Daily, Spent: sum(today, nil)})
+	}
+	for _, model := range slices.Sorted(maps.Keys(s.Models)) {
+		if limit := s.Models[model]; limit > 0 {
+			out = append(out, Usage{Scope: ScopeModel, Name: model, Limit: limit,
+				Spent: sum(today, func(sp Spend) bool { return sp.Model == model })})
+		}
+	}
+	for _, project := range slices.Sorted(maps.Keys(s.Projects)) {
+		if limit := s.Projects[project]; limit > 0 {
+			out = append(out, Usage{Scope: ScopeProject, Name: project, Limit: limit,
+				Spent: sum(today, func(sp Spend) bool { return sp.Project == project })})
+		}
+	}
+	return out
+}
+
+// Check decides whether a request to model, charged to project and
+// estimated to cost estimate, fits the budgets given today's spending. It
+// returns the budgets the request brings close to their limit, or an
+// *ExceededError if it would go over any.
+func Check(s config.BudgetSettings, today []Spend, model, project string, estimate float64) ([]Usage, error) {
+	warnAt := s.WarnAt
+	if warnAt <= 0 {
+		warnAt = defaultWarnAt
+	}
+
+	var warnings, exceeded []Usage
+	if s.PerRequest > 0 && estimate > s.PerRequest {
+		exceeded = append(exceeded, Usage{Scope: ScopePerRequest, Limit: s.PerRequest, Spent: estimate})
+	}
+	for _, u := range Status(s, today) {
+		if (u.Scope == ScopeModel && u.Name != model) || (u.Scope == ScopeProject && u.Name != project) {
+			continue
+		}
+		switch after := u.Spent + estimate; {
+		case u.Spent >= u.Limit || after > u.Limit:
+			exceeded = append(exceeded, u)
+		case after >= u.Limit*warnAt:
+			warnings = append(warnings, u)
+		}
+	}
+
+	if len(exceeded) > 0 {
+		return warnings, &ExceededError{Estimate: estimate, Exceeded: exceeded}
+	}
+	return warnings, nil
+}
+
+// Estimate predicts the cost of a request to model from the recent costs of
+// that model, or of any model if it hasn't been used. Zero means unknown.
+func Estimate(recent []Spend, model string) float64 {
+	const sample = 20
+
+	var costs, all []float64
+	for _, s := range slices.Backward(recent) {
+		if s.Cost <= 0 {
+			continue
+		}
+		if s.Model == model && len(costs) < sample {
+			costs = append(costs, s.Cost)
+		}
+		if len(all) < sample {
+			all = append(all, s.Cost)
+		}
+	}
+	if len(costs) == 0 {
+		costs = all
+	}
+	if len(costs) == 0 {
+		return 0
+	}
+
+	// The median resists the odd unusually long request
+	slices.Sort(costs)
+	return costs[len(costs)/2]
+}
+
+func sum(spends []Spend, match func(Spend) bool) float64 {
+	total := 0.0
+	for _, s := range spends {
+		if match == nil || match(s) {
+			total += s.Cost
+		}
+	}
+	return total
+}
@@ -0,0 +1,226 @@
+package budget
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/sovereynai/reign/internal/config"
+)
+
+// labels lists the budgets in usages, e.g. "Daily" or "Model llama3.2:3b"
+func labels(usages []Usage) []string {
+	var out []string
+	for _, u := range usages {
+		out = append(out, u.Label())
+	}
+	return out
+}
+
+func TestCheck(t *testing.T) {
+	spent := func(model, project string, cost float64) Spend {
+		return Spend{Model: model, Project: project, Cost: cost}
+	}
+	tests := []struct {
+		name         string
+		settings     config.BudgetSettings
+		today        []Spend
+		model        string
+		project      string
+		estimate     float64
+		wantWarnings []string
+		wantExceeded []string
+	}{
+		{
+			name:     "no budgets",
+			today:    []Spend{spent("a", "p", 100)},
+			estimate: 50,
+		},
+		{
+			name:     "under the warning level",
+			settings: config.BudgetSettings{Daily: 10},
+			today:    []Spend{spent("a", "p", 7.5)},
+			estimate: 0.25,
+		},
+		{
+			name:         "at the warning level",
+			settings:     config.BudgetSettings{Daily: 10},
+			today:        []Spend{spent("a", "p", 7.5)},
+			estimate:     0.5,
+			wantWarnings: []string{"Daily"},
+		},
+		{
+			name:         "custom warning level",
+			settings:     config.BudgetSettings{Daily: 10, WarnAt: 0.5},
+			today:        []Spend{spent("a", "p", 4)},
+			estimate:     1,
+			wantWarnings: []string{"Daily"},
+		},
+		{
+			name:         "exactly at the limit",
+			settings:     config.BudgetSettings{Daily: 10},
+			today:        []Spend{spent("a", "p", 9.5)},
+			estimate:     0.5,
+			wantWarnings: []string{"Daily"},
+		},
+		{
+			name:         "over the limit",
+			settings:     config.BudgetSettings{Daily: 10},
+			today:        []Spend{spent("a", "p", 9.5)},
+			estimate:     0.75,
+			wantExceeded: []string{"Daily"},
+		},
+		{
+			name:         "already exceeded with no estimate",
+			settings:     config.BudgetSettings{Daily: 10},
+			today:        []Spend{spent("a", "p", 10)},
+			wantExceeded: []string{"Daily"},
+		},
+		{
+			name:         "per request",
+			settings:     config.BudgetSettings{PerRequest: 0.5},
+			estimate:     0.75,
+			wantExceeded: []string{"Per request"},
+		},
+		{
+			name:     "per request at the limit",
+			settings: config.BudgetSettings{PerRequest: 0.5},
+			estimate: 0.5,
+		},
+		{
+			name:         "model budget of the request's model",
+			settings:     config.BudgetSettings{Models: map[string]float64{"a": 1, "b": 1}},
+			today:        []Spend{spent("a", "p", 1), spent("b", "p", 0.25)},
+			model:        "a",
+			estimate:     0.25,
+			wantExceeded: []string{"Model a"},
+		},
+		{
+			name:     "model budget of another model",
+			settings: config.BudgetSettings{Models: map[string]float64{"a": 1}},
+			today:    []Spend{spent("a", "p", 1)},
+			model:    "b",
+			estimate: 0.25,
+		},
+		{
+			name:         "project budget of the current project",
+			settings:     config.BudgetSettings{Projects: map[string]float64{"p": 2, "q": 2}},
+			today:        []Spend{spent("a", "p", 1.5), spent("a", "q", 0.25)},
+			model:        "a",
+			project:      "p",
+			estimate:     0.25,
+			wantWarnings: []string{"Project p"},
+		},
+		{
+			name:     "project budget of another project",
+			settings: config.BudgetSettings{Projects: map[string]float64{"q": 1}},
+			today:    []Spend{spent("a", "q", 1)},
+			project:  "p",
+			estimate: 0.25,
+		},
+		{
+			name: "warning and exceeded together",
+			settings: config.BudgetSettings{
+				Daily:  100,
+				Models: map[string]float64{"a": 1},
+			},
+			today:        []Spend{spent("a", "p", 1), spent("b", "p", 79)},
+			model:        "a",
+			estimate:     0.5,
+			wantWarnings: []string{"Daily"},
+			wantExceeded: []string{"Model a"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings, err := Check(tt.settings, tt.today, tt.model, tt.project, tt.estimate)
+			if got := labels(warnings); !slices.Equal(got, tt.wantWarnings) {
+				t.Errorf("Check() warnings = %q, want %q", got, tt.wantWarnings)
+			}
+
+			var exceeded *ExceededError
+			if err != nil && !errors.As(err, &exceeded) {
+				t.Fatalf("Check() error = %v, want an *ExceededError", err)
+			}
+			var got []string
+			if exceeded != nil {
+				got = labels(exceeded.Exceeded)
+				if exceeded.Estimate != tt.estimate {
+					t.Errorf("ExceededError.Estimate = %v, want %v", exceeded.Estimate, tt.estimate)
+				}
+			}
+			if !slices.Equal(got, tt.wantExceeded) {
+				t.Errorf("Check() exceeded = %q, want %q", got, tt.wantExceeded)
+			}
+		})
+	}
+}
+
+func TestEstimate(t *testing.T) {
+	costs := func(model string, costs ...float64) []Spend {
+		var out []Spend
+		for _, c := range costs {
+			out = append(out, Spend{Model: model, Cost: c})
+		}
+		return out
+	}
+	tests := []struct {
+		name   string
+		recent []Spend
+		model  string
+		want   float64
+	}{
+		{name: "no history", model: "a", want: 0},
+		{name: "median of the model", recent: costs("a", 1, 100, 2), model: "a", want: 2},
+		{name: "upper median of an even count", recent: costs("a", 1, 2, 3, 4), model: "a", want: 3},
+		{
+			name:   "ignores other models",
+			recent: append(costs("a", 1, 1, 1), costs("b", 9, 9, 9, 9, 9)...),
+			model:  "a",
+			want:   1,
+		},
+		{
+			name:   "falls back to all models",
+			recent: append(costs("a", 1, 2), costs("b", 3)...),
+			model:  "c",
+			want:   2,
+		},
+		{name: "skips free requests", recent: costs("a", 0, 0, 0, 4), model: "a", want: 4},
+		{
+			name:   "most recent twenty",
+			recent: append(costs("a", 50, 50, 50, 50, 50, 50, 50, 50, 50, 50), costs("a", 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1)...),
+			model:  "a",
+			want:   1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Estimate(tt.recent, tt.model); got != tt.want {
+				t.Errorf("Estimate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStatus(t *testing.T) {
+	settings := config.BudgetSettings{
+		Daily:      10,
+		PerRequest: 1,
+		Models:     map[string]float64{"b": 2, "a": 3, "off": 0},
+		Projects:   map[string]float64{"p": 4},
+	}
+	today := []Spend{
+		{Model: "a", Project: "p", Cost: 1},
+		{Model: "b", Project: "q", Cost: 0.5},
+		{Model: "a", Project: "q", Cost: 0.25},
+	}
+	want := []Usage{
+		{Scope: ScopeDaily, Limit: 10, Spent: 1.75},
+		{Scope: ScopeModel, Name: "a", Limit: 3, Spent: 1.25},
+		{Scope: ScopeModel, Name: "b", Limit: 2, Spent: 0.5},
+		{Scope: ScopeProject, Name: "p", Limit: 4, Spent: 1},
+	}
+	if got := Status(settings, today); !slices.Equal(got, want) {
+		t.Errorf("Status() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,82 @@
+package budget
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/sovereynai/reign/internal/config"
+)
+
+// Spend is the cost of one request
+type Spend struct {
+	Time    time.Time `json:"time"`
+	Model   string    `json:"model"`
+	Project string    `json:"project,omitempty"`
+	Cost    float64   `json:"cost"`
+}
+
+// Ledger is an append-only JSON Lines file of spends. It is kept apart from
+// the request history so budgets work even with history disabled.
+type Ledger struct {
+	Path string
+}
+
+// DefaultLedger returns the ledger under the sovereyn home
+func DefaultLedger() *Ledger {
+	return &Ledger{Path: filepath.Join(config.Home(), "budget", "spend.jsonl")}
+}
+
+// Record appends a spend to the ledger
+func (l *Ledger) Record(s Spend) error {
+	line, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("failed to encode spend: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(l.Path), 0755); err != nil {
+		return fmt.Errorf("failed to create budget directory: %w", err)
+	}
+
+	f, err := os.OpenFile(l.Path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open spend ledger: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write spend: %w", err)
+	}
+	return nil
+}
+
+// Since returns all spends at or after t, oldest first. Lines that cannot be
+// decoded (e.g. a partial write) are skipped.
+func (l *Ledger) Since(t time.Time) ([]Spend, error) {
+	f, err := os.Open(l.Path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open spend ledger: %w", err)
+	}
+	defer f.Close()
+
+	var spends []Spend
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var s Spend
+		if err := json.Unmarshal(scanner.Bytes(), &s); err != nil || s.Time.Before(t) {
+			continue
+		}
+		spends = append(spends, s)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read spend ledger: %w", err)
+	}
+
+	return spends, nil
+}
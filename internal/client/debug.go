@@ -0,0 +1,196 @@
+package client
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RequestIDHeader carries the ID reign generates for every throne request,
+// so failures can be matched with throne's logs
+const RequestIDHeader = "X-Request-ID"
+
+// maxLoggedBody is how much of a request or response body is logged
+const maxLoggedBody = 4 * 1024
+
+var (
+	loggerMu sync.Mutex
+	logger   *slog.Logger // Nil while HTTP logging is off
+)
+
+// SetLogger enables logging of throne HTTP traffic. Requests are logged at
+// info level; redacted bodies at debug level. A nil logger turns it off.
+func SetLogger(l *slog.Logger) {
+	loggerMu.Lock()
+	defer loggerMu.Unlock()
+	logger = l
+}
+
+func currentLogger() *slog.Logger {
+	loggerMu.Lock()
+	defer loggerMu.Unlock()
+	return logger
+}
+
+// requestTransport tags each request with an ID and logs the exchange when
+// a logger is set
+type requestTransport struct {
+	base http.RoundTripper
+}
+
+func (t *requestTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	id := req.Header.Get(RequestIDHeader)
+	if id == "" {
+		id = newRequestID()
+		req = req.Clone(req.Context())
+		req.Header.Set(RequestIDHeader, id)
+	}
+
+	log := currentLogger()
+	if log != nil {
+		log = log.With("request_id", id)
+		log.Info("throne request", "method", req.Method, "url", req.URL.Redacted())
+		if log.Enabled(req.Context(), slog.LevelDebug) && req.GetBody != nil {
+			if body, err := req.GetBody(); err == nil {
+				data, _ := io.ReadAll(io.LimitReader(body, maxLoggedBody+1))
+				body.Close()
+				log.Debug("throne request body", "body", redactBody(data))
+			}
+		}
+	}
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		if log != nil {
+			log.Info("throne request failed", "duration", time.Since(start), "error", err)
+		}
+		return nil, fmt.Errorf("%w (request ID %s)", err, id)
+	}
+	if log != nil {
+		log.Info("throne response", "status", resp.StatusCode, "duration", time.Since(start))
+		if log.Enabled(req.Context(), slog.LevelDebug) {
+			resp.Body = &loggedBody{
+				ReadCloser: resp.Body,
+				log:        log,
+				start:      start,
+				stream:     strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream"),
+			}
+		}
+	}
+	return resp, nil
+}
+
+// loggedBody keeps the start of a response body and logs it on close
+type loggedBody struct {
+	io.ReadCloser
+	log    *slog.Logger
+	start  time.Time
+	stream bool // Event streams only have their size logged
+	buf    bytes.Buffer
+	size   int64
+}
+
+func (b *loggedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.size += int64(n)
+	if !b.stream && b.buf.Len() <= maxLoggedBody {
+		b.buf.Write(p[:n])
+	}
+	return n, err
+}
+
+func (b *loggedBody) Close() error {
+	err := b.ReadCloser.Close()
+	attrs := []any{"bytes", b.size, "duration", time.Since(b.start)}
+	if !b.stream {
+		attrs = append(attrs, "body", redactBody(b.buf.Bytes()))
+	}
+	b.log.Debug("throne response body", attrs...)
+	return err
+}
+
+// sensitiveKeys are JSON fields whose values are never logged, along with
+// any field ending in one of sensitiveSuffixes
+var (
+	sensitiveKeys     = []string{"token", "secret", "password", "authorization", "api_key", "apikey", "private_key", "seed", "mnemonic"}
+	sensitiveSuffixes = []string{"_token", "_secret", "_password"}
+)
+
+// redactBody prepares a body for logging: sensitive JSON fields are masked
+// and long bodies truncated
+func redactBody(data []byte) string {
+	truncated := len(data) > maxLoggedBody
+	if truncated {
+		data = data[:maxLoggedBody]
+	}
+
+	var v any
+	if !truncated && json.Unmarshal(data, &v) == nil {
+		if out, err := json.Marshal(redactValue(v)); err == nil {
+			return string(out)
+		}
+	}
+	s := string(data)
+	if truncated {
+		s += "...(truncated)"
+	}
+	return s
+}
+
+func redactValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, inner := range v {
+			if isSensitive(k) {
+				v[k] = "[REDACTED]"
+			} else {
+				v[k] = redactValue(inner)
+			}
+		}
+	case []any:
+		for i, inner := range v {
+			v[i] = redactValue(inner)
+		}
+	}
+	return v
+}
+
+func isSensitive(key string) bool {
+	key = strings.ToLower(key)
+	if slices.Contains(sensitiveKeys, key) {
+		return true
+	}
+	for _, suffix := range sensitiveSuffixes {
+		if strings.HasSuffix(key, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+func newRequestID() string {
+	var b [8]byte
+	rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// withRequestID appends the ID of the request behind resp to err
+func withRequestID(err error, resp *http.Response) error {
+	if resp == nil || resp.Request == nil {
+		return err
+	}
+	if id := resp.Request.Header.Get(RequestIDHeader); id != "" {
+		return fmt.Errorf("%w (request ID %s)", err, id)
+	}
+	return err
+}
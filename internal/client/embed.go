@@ -0,0 +1,60 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// EmbedRequest asks for embeddings of one or more texts
+type EmbedRequest struct {
+	Model string   `json:"model"`
+	Input []string `json:"input"`
+}
+
+// EmbedResponse holds one embedding per input, in input order
+type EmbedResponse struct {
+	Model      string      `json:"model"`
+	Embeddings [][]float64 `json:"embeddings"`
+	NodeID     string      `json:"node_id,omitempty"` // Node that served the request
+	Cost       float64     `json:"cost,omitempty"`    // Credits charged
+}
+
+// Embed computes embeddings of texts with an embedding model
+func (c *ThroneClient) Embed(ctx context.Context, req EmbedRequest) (*EmbedResponse, error) {
+	reqBody, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.BaseURL+"/embed", bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create embed request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send embed request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, withRequestID(fmt.Errorf("embed request failed: %s: %s", resp.Status, strings.TrimSpace(string(body))), resp)
+	}
+
+	var embedResp EmbedResponse
+	if err := json.NewDecoder(resp.Body).Decode(&embedResp); err != nil {
+		return nil, withRequestID(fmt.Errorf("failed to decode embeddings: %w", err), resp)
+	}
+	if len(embedResp.Embeddings) != len(req.Input) {
+		return nil, withRequestID(fmt.Errorf("got %d embeddings for %d inputs", len(embedResp.Embeddings), len(req.Input)), resp)
+	}
+
+	return &embedResp, nil
+}
@@ -0,0 +1,238 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sovereynai/reign/internal/tracing"
+)
+
+// Event types sent on throne's event stream
+const (
+	EventJob   = "job"   // A job changed state (JobEvent)
+	EventPeer  = "peer"  // A peer joined or left the network (PeerEvent)
+	EventModel = "model" // A model became available or unavailable (ModelEvent)
+	EventAlert = "alert" // A node raised an alert (AlertEvent)
+)
+
+// EventTypes lists every event type
+var EventTypes = []string{EventJob, EventPeer, EventModel, EventAlert}
+
+// Event is one message from the event stream. Decode Data into the payload
+// type matching Type.
+type Event struct {
+	ID   string          `json:"id"` // Resume token
+	Type string          `json:"type"`
+	Time time.Time       `json:"time"` // When the event was received
+	Data json.RawMessage `json:"data"`
+}
+
+// Decode unmarshals the event payload into v
+func (e Event) Decode(v any) error {
+	if err := json.Unmarshal(e.Data, v); err != nil {
+		return fmt.Errorf("failed to decode %s event: %w", e.Type, err)
+	}
+	return nil
+}
+
+// JobEvent reports a job state change
+type JobEvent struct {
+	LiveJob
+	PreviousStatus string `json:"previous_status,omitempty"`
+}
+
+// PeerEvent reports a peer joining or leaving
+type PeerEvent struct {
+	NodeID  string `json:"node_id"`
+	Address string `json:"address,omitempty"`
+	Action  string `json:"action"` // "joined" or "left"
+}
+
+// ModelEvent reports a change in where a model is available
+type ModelEvent struct {
+	Model     string `json:"model"`
+	NodeID    string `json:"node_id"`
+	Available bool   `json:"available"`
+}
+
+// AlertEvent is an alert raised by a node
+type AlertEvent struct {
+	Alert
+	NodeID string `json:"node_id,omitempty"`
+}
+
+// SubscribeOptions configures an event subscription
+type SubscribeOptions struct {
+	Types       []string // Event types to receive; empty means all
+	LastEventID string   // Resume after this event instead of starting live
+
+	// OnError is told about each dropped connection or failed attempt
+	// before Subscribe reconnects. Optional.
+	OnError func(err error, retryIn time.Duration)
+}
+
+const (
+	minReconnectDelay = time.Second
+	maxReconnectDelay = 30 * time.Second
+)
+
+// Subscribe streams server-sent events from throne to handle until ctx is
+// cancelled or handle returns an error. Dropped connections are retried
+// with backoff, resuming after the last event received.
+func (c *ThroneClient) Subscribe(ctx context.Context, opts SubscribeOptions, handle func(Event) error) error {
+	// Streams stay open indefinitely, so don't use the request timeout
+	stream := &http.Client{Transport: c.client.Transport}
+
+	var handlerErr error
+	deliver := func(e Event) error {
+		handlerErr = handle(e)
+		return handlerErr
+	}
+
+	lastID := opts.LastEventID
+	delay := minReconnectDelay
+	for {
+		connected, retry, err := c.readEvents(ctx, stream, opts.Types, &lastID, deliver)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if handlerErr != nil {
+			return handlerErr
+		}
+
+		// Back off from scratch after a working connection, and let the
+		// server override the delay
+		if connected {
+			delay = minReconnectDelay
+		}
+		if retry > 0 {
+			delay = retry
+		}
+		if err == nil {
+			err = fmt.Errorf("event stream closed")
+		}
+		if opts.OnError != nil {
+			opts.OnError(err, delay)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, maxReconnectDelay)
+	}
+}
+
+// readEvents runs one connection to the event stream. connected reports
+// whether the server accepted the subscription; retry is the reconnect
+// delay requested by the server, if any.
+func (c *ThroneClient) readEvents(ctx context.Context, stream *http.Client, types []string, lastID *string, handle func(Event) error) (connected bool, retry time.Duration, err error) {
+	ctx, span := tracing.Start(ctx, "throne.events.connection")
+	received := 0
+	defer func() {
+		span.SetAttr("events.received", received)
+		span.SetAttr("events.last_id", *lastID)
+		span.SetError(err)
+		span.End()
+	}()
+
+	query := url.Values{}
+	if len(types) > 0 {
+		query.Set("types", strings.Join(types, ","))
+	}
+	endpoint := c.BaseURL + "/events"
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return false, 0, fmt.Errorf("failed to create events request: %w", err)
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("Cache-Control", "no-cache")
+	if *lastID != "" {
+		req.Header.Set("Last-Event-ID", *lastID)
+	}
+
+	resp, err := stream.Do(req)
+	if err != nil {
+		return false, 0, fmt.Errorf("failed to connect to event stream: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return false, 0, withRequestID(fmt.Errorf("failed to subscribe to events: %s: %s", resp.Status, strings.TrimSpace(string(body))), resp)
+	}
+
+	// Parse the text/event-stream format: fields until a blank line
+	var event Event
+	var data strings.Builder
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			if data.Len() > 0 {
+				event.Data = json.RawMessage(data.String())
+				if event.Type == "" {
+					event.Type = "message"
+				}
+				if event.Time.IsZero() {
+					event.Time = time.Now()
+				}
+				if event.ID != "" {
+					*lastID = event.ID
+				}
+				if received == 0 {
+					span.AddEvent("first_event")
+				}
+				received++
+				if len(types) == 0 || slices.Contains(types, event.Type) {
+					if err := handle(event); err != nil {
+						return true, retry, err
+					}
+				}
+			}
+			event = Event{}
+			data.Reset()
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			continue // Comment / keep-alive
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "id":
+			event.ID = value
+		case "event":
+			event.Type = value
+		case "data":
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(value)
+		case "retry":
+			if ms, err := strconv.Atoi(value); err == nil && ms > 0 {
+				retry = time.Duration(ms) * time.Millisecond
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return true, retry, withRequestID(fmt.Errorf("event stream interrupted: %w", err), resp)
+	}
+	return true, retry, nil
+}
@@ -0,0 +1,174 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Limits are the rate limits and quotas that apply to this account
+type Limits struct {
+	Account []Limit       `json:"account"`
+	Models  []ModelLimits `json:"models"`
+}
+
+// ModelLimits are the limits on a single model
+type ModelLimits struct {
+	Model  string  `json:"model"`
+	Limits []Limit `json:"limits"`
+}
+
+// Limit is one rate limit or quota and its current usage
+type Limit struct {
+	Name     string    `json:"name"`   // What is limited, e.g. "requests", "tokens" or "credits"
+	Window   string    `json:"window"` // e.g. "minute", "day" or "month"
+	Limit    float64   `json:"limit"`
+	Used     float64   `json:"used"`
+	ResetsAt time.Time `json:"resets_at"`
+}
+
+// Remaining is how much of the limit is left in the current window
+func (l Limit) Remaining() float64 {
+	return max(0, l.Limit-l.Used)
+}
+
+// UsedFraction is the share of the limit used, from 0 to 1
+func (l Limit) UsedFraction() float64 {
+	if l.Limit <= 0 {
+		return 0
+	}
+	return min(1, l.Used/l.Limit)
+}
+
+// GetLimits fetches the account's rate limits, quotas and current usage
+func (c *ThroneClient) GetLimits() (*Limits, error) {
+	resp, err := c.client.Get(c.BaseURL + "/limits")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get limits: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, withRequestID(fmt.Errorf("this throne version does not report rate limits; upgrade throne to see them"), resp)
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, withRequestID(fmt.Errorf("failed to get limits: %s: %s", resp.Status, strings.TrimSpace(string(body))), resp)
+	}
+
+	var limits Limits
+	if err := json.NewDecoder(resp.Body).Decode(&limits); err != nil {
+		return nil, withRequestID(fmt.Errorf("failed to decode limits: %w", err), resp)
+	}
+
+	return &limits, nil
+}
+
+// RateLimitError is returned when throne rejects a request with 429 Too
+// Many Requests and it can't be retried automatically
+type RateLimitError struct {
+	RetryAfter time.Duration // Zero when throne didn't say
+	Message    string
+}
+
+func (e *RateLimitError) Error() string {
+	msg := "rate limited by throne"
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	if e.RetryAfter > 0 {
+		msg += fmt.Sprintf("; retry in %s", e.RetryAfter.Round(time.Second))
+	}
+	return msg + " (run 'reign dev limits' for details)"
+}
+
+// Requests that hit a rate limit are retried when throne asks for a short
+// enough wait
+const (
+	maxRateLimitRetries = 2
+	maxRetryWait        = 10 * time.Second
+	defaultRetryWait    = time.Second // When there's no Retry-After header
+)
+
+// rateLimitTransport waits and retries requests rejected with 429, and turns
+// the rejection into a RateLimitError once it gives up
+type rateLimitTransport struct {
+	base http.RoundTripper
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := t.base.RoundTrip(req)
+		if err != nil || resp.StatusCode != http.StatusTooManyRequests {
+			return resp, err
+		}
+
+		retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+		wait := retryAfter
+		if !ok {
+			wait = defaultRetryWait
+		}
+		rewindable := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+		if attempt == maxRateLimitRetries || wait > maxRetryWait || !rewindable {
+			body, _ := io.ReadAll(io.LimitReader(resp.Body, maxLoggedBody))
+			resp.Body.Close()
+			return nil, &RateLimitError{RetryAfter: retryAfter, Message: rateLimitMessage(body)}
+		}
+		resp.Body.Close()
+
+		if log := currentLogger(); log != nil {
+			log.Info("throne rate limited, retrying", "request_id", req.Header.Get(RequestIDHeader), "wait", wait, "attempt", attempt+1)
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("failed to rewind request body: %w", err)
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
+
+// parseRetryAfter reads a Retry-After header, given either in seconds or as
+// an HTTP date
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(value); err == nil {
+		return time.Duration(max(0, secs)) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(0, at.Sub(now)), true
+	}
+	return 0, false
+}
+
+// rateLimitMessage extracts throne's explanation from a 429 response body
+func rateLimitMessage(body []byte) string {
+	var v struct {
+		Error   string `json:"error"`
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(body, &v) == nil {
+		if v.Error != "" {
+			return v.Error
+		}
+		return v.Message
+	}
+	return strings.TrimSpace(string(body))
+}
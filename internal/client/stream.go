@@ -0,0 +1,86 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// StreamChat sends a chat request and calls onDelta with each piece of the
+// response as throne generates it. The returned response carries the full
+// content along with the final chunk's latency, node and cost.
+func (c *ThroneClient) StreamChat(ctx context.Context, req ChatRequest, onDelta func(string)) (*ChatResponse, error) {
+	req.Stream = true
+	reqBody, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.BaseURL+"/chat", bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create chat request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	// Generation can outlast the request timeout, so don't use it
+	stream := &http.Client{Transport: c.client.Transport}
+	start := time.Now()
+	resp, err := stream.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send chat request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, withRequestID(fmt.Errorf("chat request failed: %s: %s", resp.Status, strings.TrimSpace(string(body))), resp)
+	}
+
+	// Chunks are newline-delimited JSON, optionally as server-sent events
+	var final ChatResponse
+	var content strings.Builder
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		line = strings.TrimPrefix(line, "data:")
+		line = strings.TrimSpace(line)
+		if line == "" || line == "[DONE]" {
+			continue
+		}
+
+		var chunk ChatResponse
+		if err := json.Unmarshal([]byte(line), &chunk); err != nil {
+			return nil, withRequestID(fmt.Errorf("failed to decode response chunk: %w", err), resp)
+		}
+		if chunk.Error != "" {
+			return nil, withRequestID(errors.New(chunk.Error), resp)
+		}
+		if chunk.Message.Content != "" {
+			content.WriteString(chunk.Message.Content)
+			onDelta(chunk.Message.Content)
+		}
+		final = chunk
+		if chunk.Done {
+			break
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, withRequestID(fmt.Errorf("chat stream interrupted: %w", err), resp)
+	}
+
+	final.Message.Role = "assistant"
+	final.Message.Content = content.String()
+	final.Success = true
+	if final.LatencyMs == 0 {
+		final.LatencyMs = time.Since(start).Milliseconds()
+	}
+	return &final, nil
+}
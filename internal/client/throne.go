@@ -2,11 +2,16 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
+
+	"github.com/sovereynai/reign/internal/tracing"
 )
 
 // ThroneClient communicates with the throne daemon
@@ -19,7 +24,10 @@ type ThroneClient struct {
 func NewThroneClient(baseURL string) *ThroneClient {
 	return &ThroneClient{
 		BaseURL: baseURL,
-		client:  &http.Client{Timeout: 60 * time.Second},
+		client: &http.Client{
+			Timeout:   60 * time.Second,
+			Transport: &requestTransport{base: &rateLimitTransport{base: tracing.Transport(nil)}},
+		},
 	}
 }
 
@@ -32,8 +40,10 @@ type VersionInfo struct {
 
 // ChatMessage represents a message in the conversation
 type ChatMessage struct {
-	Role    string `json:"role"`
-	Content string `json:"content"`
+	Role      string     `json:"role"`
+	Content   string     `json:"content"`
+	ToolCalls []ToolCall `json:"tool_calls,omitempty"` // Tools the assistant asked to call
+	ToolName  string     `json:"tool_name,omitempty"`  // For role "tool": the tool whose result this is
 }
 
 // ChatRequest for LLM inference
@@ -41,17 +51,26 @@ type ChatRequest struct {
 	Model    string        `json:"model"`
 	Messages []ChatMessage `json:"messages"`
 	Stream   bool          `json:"stream"`
+	Options  *ChatOptions  `json:"options,omitempty"`
+	Tools    []Tool        `json:"tools,omitempty"` // Tools the model may call
+}
+
+// ChatOptions are generation parameters passed through to the model
+type ChatOptions struct {
+	Temperature *float64 `json:"temperature,omitempty"`
+	MaxTokens   int      `json:"num_predict,omitempty"`
 }
 
 // ChatResponse from throne
 type ChatResponse struct {
-	Message struct {
-		Role    string `json:"role"`
-		Content string `json:"content"`
-	} `json:"message"`
-	Model     string `json:"model"`
-	Success   bool   `json:"success"`
-	LatencyMs int64  `json:"latency_ms"`
+	Message   ChatMessage `json:"message"`
+	Model     string      `json:"model"`
+	Success   bool        `json:"success"`
+	LatencyMs int64       `json:"latency_ms"`
+	NodeID    string      `json:"node_id,omitempty"` // Node that served the request
+	Cost      float64     `json:"cost,omitempty"`    // Credits charged
+	Done      bool        `json:"done,omitempty"`    // Last chunk of a streamed response
+	Error     string      `json:"error,omitempty"`
 }
 
 // ModelInfo represents an available model
@@ -227,7 +246,7 @@ func (c *ThroneClient) GetVersion() (*VersionInfo, error) {
 
 	var version VersionInfo
 	if err := json.NewDecoder(resp.Body).Decode(&version); err != nil {
-		return nil, fmt.Errorf("failed to decode version: %w", err)
+		return nil, withRequestID(fmt.Errorf("failed to decode version: %w", err), resp)
 	}
 
 	return &version, nil
@@ -235,18 +254,34 @@ func (c *ThroneClient) GetVersion() (*VersionInfo, error) {
 
 // Chat sends a chat request to throne
 func (c *ThroneClient) Chat(model, prompt string) (*ChatResponse, error) {
-	reqBody, err := json.Marshal(ChatRequest{
+	return c.SendChat(ChatRequest{
 		Model: model,
 		Messages: []ChatMessage{
 			{Role: "user", Content: prompt},
 		},
 		Stream: false,
 	})
+}
+
+// SendChat sends a full chat request to throne
+func (c *ThroneClient) SendChat(req ChatRequest) (*ChatResponse, error) {
+	return c.SendChatContext(context.Background(), req)
+}
+
+// SendChatContext is SendChat with a context to cancel the request
+func (c *ThroneClient) SendChatContext(ctx context.Context, req ChatRequest) (*ChatResponse, error) {
+	reqBody, err := json.Marshal(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	resp, err := c.client.Post(c.BaseURL+"/chat", "application/json", bytes.NewReader(reqBody))
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.BaseURL+"/chat", bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create chat request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(httpReq)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send chat request: %w", err)
 	}
@@ -254,7 +289,7 @@ func (c *ThroneClient) Chat(model, prompt string) (*ChatResponse, error) {
 
 	var chatResp ChatResponse
 	if err := json.NewDecoder(resp.Body).Decode(&chatResp); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+		return nil, withRequestID(fmt.Errorf("failed to decode response: %w", err), resp)
 	}
 
 	return &chatResp, nil
@@ -270,7 +305,7 @@ func (c *ThroneClient) ListModels() ([]string, error) {
 
 	var models []string
 	if err := json.NewDecoder(resp.Body).Decode(&models); err != nil {
-		return nil, fmt.Errorf("failed to decode models: %w", err)
+		return nil, withRequestID(fmt.Errorf("failed to decode models: %w", err), resp)
 	}
 
 	return models, nil
@@ -286,7 +321,7 @@ func (c *ThroneClient) Health() error {
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("throne daemon unhealthy: %s", string(body))
+		return withRequestID(fmt.Errorf("throne daemon unhealthy: %s", string(body)), resp)
 	}
 
 	return nil
@@ -302,7 +337,7 @@ func (c *ThroneClient) GetDashboardStats() (*DashboardStats, error) {
 
 	var stats DashboardStats
 	if err := json.NewDecoder(resp.Body).Decode(&stats); err != nil {
-		return nil, fmt.Errorf("failed to decode dashboard stats: %w", err)
+		return nil, withRequestID(fmt.Errorf("failed to decode dashboard stats: %w", err), resp)
 	}
 
 	return &stats, nil
@@ -327,7 +362,7 @@ func (c *ThroneClient) ListNetworkModels() ([]NetworkModel, error) {
 
 	var models []NetworkModel
 	if err := json.NewDecoder(resp.Body).Decode(&models); err != nil {
-		return nil, fmt.Errorf("failed to decode network models: %w", err)
+		return nil, withRequestID(fmt.Errorf("failed to decode network models: %w", err), resp)
 	}
 
 	return models, nil
@@ -344,7 +379,7 @@ func (c *ThroneClient) LocateModel(modelName string) ([]map[string]interface{},
 
 	var result map[string]interface{}
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to decode location response: %w", err)
+		return nil, withRequestID(fmt.Errorf("failed to decode location response: %w", err), resp)
 	}
 
 	locations, ok := result["locations"].([]interface{})
@@ -359,3 +394,72 @@ func (c *ThroneClient) LocateModel(modelName string) ([]map[string]interface{},
 
 	return locs, nil
 }
+
+// LiveJob is an inference job as reported by the live jobs endpoint
+type LiveJob struct {
+	ID        string  `json:"id"`
+	Model     string  `json:"model"`
+	ModelType string  `json:"model_type"` // "ollama" or "onnx"
+	Status    string  `json:"status"`     // "queued", "running", "completed", "failed"
+	Progress  float64 `json:"progress"`   // 0.0 to 1.0
+	StartTime string  `json:"start_time"` // RFC 3339
+	Duration  int64   `json:"duration_ms"`
+	NodeID    string  `json:"node_id"`
+
+	// Progress details, when throne can report them
+	TokensGenerated int     `json:"tokens_generated,omitempty"`
+	MaxTokens       int     `json:"max_tokens,omitempty"`
+	TokensPerSec    float64 `json:"tokens_per_sec,omitempty"`
+	BatchDone       int     `json:"batch_done,omitempty"`  // ONNX items processed
+	BatchTotal      int     `json:"batch_total,omitempty"` // ONNX items in the batch
+	EtaMs           int64   `json:"eta_ms,omitempty"`      // Server estimate of time remaining
+
+	PromptPreview string  `json:"prompt_preview,omitempty"` // Start of the prompt
+	Cost          float64 `json:"cost,omitempty"`           // Credits charged
+	Error         string  `json:"error,omitempty"`          // Failure reason
+	QueuedAt      string  `json:"queued_at,omitempty"`      // RFC 3339
+	FinishedAt    string  `json:"finished_at,omitempty"`    // RFC 3339
+}
+
+// LiveJobsResponse lists active jobs and recently finished ones
+type LiveJobsResponse struct {
+	Active []LiveJob `json:"active"`
+	Recent []LiveJob `json:"recent"`
+}
+
+// GetLiveJobs fetches the jobs currently running on the node and recent history
+func (c *ThroneClient) GetLiveJobs() (*LiveJobsResponse, error) {
+	resp, err := c.client.Get(c.BaseURL + "/jobs/live")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get live jobs: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, withRequestID(fmt.Errorf("failed to get live jobs: %s: %s", resp.Status, strings.TrimSpace(string(body))), resp)
+	}
+
+	var jobs LiveJobsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&jobs); err != nil {
+		return nil, withRequestID(fmt.Errorf("failed to decode live jobs: %w", err), resp)
+	}
+
+	return &jobs, nil
+}
+
+// CancelJob asks throne to cancel a queued or running job
+func (c *ThroneClient) CancelJob(id string) error {
+	resp, err := c.client.Post(c.BaseURL+"/jobs/"+url.PathEscape(id)+"/cancel", "application/json", nil)
+	if err != nil {
+		return fmt.Errorf("failed to cancel job: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
+		body, _ := io.ReadAll(resp.Body)
+		return withRequestID(fmt.Errorf("failed to cancel job: %s: %s", resp.Status, strings.TrimSpace(string(body))), resp)
+	}
+
+	return nil
+}
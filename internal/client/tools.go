@@ -0,0 +1,61 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Tool is a function a model may call, described to it in a chat request
+type Tool struct {
+	Type     string       `json:"type"` // Always "function"
+	Function ToolFunction `json:"function"`
+}
+
+// ToolFunction describes a tool's name, purpose and arguments
+type ToolFunction struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	Parameters  map[string]any `json:"parameters"` // JSON Schema of the arguments
+}
+
+// ToolCall is a model's request to call a tool
+type ToolCall struct {
+	ID       string           `json:"id,omitempty"`
+	Function ToolCallFunction `json:"function"`
+}
+
+// ToolCallFunction is the tool to call and its arguments
+type ToolCallFunction struct {
+	Name      string         `json:"name"`
+	Arguments map[string]any `json:"arguments"`
+}
+
+// UnmarshalJSON accepts arguments as an object or, as some models send
+// them, as a string holding one
+func (f *ToolCallFunction) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Name      string          `json:"name"`
+		Arguments json.RawMessage `json:"arguments"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	f.Name = raw.Name
+	f.Arguments = nil
+
+	args := raw.Arguments
+	var encoded string
+	if json.Unmarshal(args, &encoded) == nil {
+		if encoded == "" {
+			return nil
+		}
+		args = json.RawMessage(encoded)
+	}
+	if len(args) == 0 || string(args) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(args, &f.Arguments); err != nil {
+		return fmt.Errorf("invalid arguments for tool %s: %w", raw.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// VisionRequest asks a vision model to classify an image
+type VisionRequest struct {
+	Model string `json:"model"`
+	Image string `json:"image"`           // Base64-encoded image file
+	TopK  int    `json:"top_k,omitempty"` // Number of labels to return
+}
+
+// VisionPrediction is a label and how likely the image shows it
+type VisionPrediction struct {
+	Label string  `json:"label"`
+	Score float64 `json:"score"`
+}
+
+// VisionResponse holds the predictions, most likely first
+type VisionResponse struct {
+	Model       string             `json:"model"`
+	Predictions []VisionPrediction `json:"predictions"`
+	LatencyMs   int64              `json:"latency_ms"`
+	NodeID      string             `json:"node_id,omitempty"` // Node that served the request
+	Cost        float64            `json:"cost,omitempty"`    // Credits charged
+}
+
+// ClassifyImage runs an image classification model on an image
+func (c *ThroneClient) ClassifyImage(ctx context.Context, req VisionRequest) (*VisionResponse, error) {
+	reqBody, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.BaseURL+"/vision/classify", bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create vision request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send vision request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, withRequestID(fmt.Errorf("vision request failed: %s: %s", resp.Status, strings.TrimSpace(string(body))), resp)
+	}
+
+	var visionResp VisionResponse
+	if err := json.NewDecoder(resp.Body).Decode(&visionResp); err != nil {
+		return nil, withRequestID(fmt.Errorf("failed to decode predictions: %w", err), resp)
+	}
+
+	return &visionResp, nil
+}
@@ -1,10 +1,13 @@
 package config
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"os"
 	"time"
+
+	"github.com/sovereynai/reign/internal/tracing"
 )
 
 // Config holds reign CLI configuration
@@ -14,8 +17,13 @@ type Config struct {
 
 // Load discovers the throne daemon URL
 func Load() (*Config, error) {
+	ctx, span := tracing.Start(context.Background(), "config.Load")
+	defer span.End()
+
 	// Check environment variable first
 	if url := os.Getenv("THRONE_URL"); url != "" {
+		span.SetAttr("throne.url", url)
+		span.SetAttr("discovery.source", "env")
 		return &Config{ThroneURL: url}, nil
 	}
 
@@ -27,17 +35,25 @@ func Load() (*Config, error) {
 	}
 
 	for _, url := range defaultURLs {
-		if isReachable(url) {
+		if isReachable(ctx, url) {
+			span.SetAttr("throne.url", url)
+			span.SetAttr("discovery.source", "probe")
 			return &Config{ThroneURL: url}, nil
 		}
 	}
 
-	return nil, fmt.Errorf("throne daemon not found. Start with: throne serve")
+	err := fmt.Errorf("throne daemon not found. Start with: throne serve")
+	span.SetError(err)
+	return nil, err
 }
 
-func isReachable(url string) bool {
-	client := &http.Client{Timeout: 1 * time.Second}
-	resp, err := client.Get(url + "/healthz")
+func isReachable(ctx context.Context, url string) bool {
+	client := &http.Client{Timeout: 1 * time.Second, Transport: tracing.Transport(nil)}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url+"/healthz", nil)
+	if err != nil {
+		return false
+	}
+	resp, err := client.Do(req)
 	if err != nil {
 		return false
 	}
@@ -0,0 +1,148 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Settings holds user preferences stored in reign.json under the sovereyn home
+type Settings struct {
+	Theme   string                 `json:"theme,omitempty"`    // "dark", "light", "high-contrast" or a custom theme name
+	NoColor bool                   `json:"no_color,omitempty"` // Disable colors and text attributes
+	ASCII   bool                   `json:"ascii,omitempty"`    // Replace emoji and box-drawing characters
+	Themes  map[string]ThemeColors `json:"themes,omitempty"`   // Custom themes by name
+
+	RecordStats bool `json:"record_stats,omitempty"` // Record a stats snapshot whenever a dashboard is shown
+
+	Layout Layout `json:"layout,omitempty"` // Dashboard sections, columns and quick actions
+
+	Tracing TracingSettings `json:"tracing,omitempty"` // Where to export OpenTelemetry traces
+
+	History HistorySettings `json:"history,omitempty"` // What is kept of past requests
+
+	Budget BudgetSettings `json:"budget,omitempty"` // Credit spending limits
+}
+
+// BudgetSettings caps credit spending before requests are sent. Amounts are
+// in credits; zero means no limit.
+type BudgetSettings struct {
+	Daily      float64            `json:"daily,omitempty"`       // Total spend per day
+	PerRequest float64            `json:"per_request,omitempty"` // Estimated cost of a single request
+	Models     map[string]float64 `json:"models,omitempty"`      // Spend per model per day
+	Projects   map[string]float64 `json:"projects,omitempty"`    // Spend per project per day
+	WarnAt     float64            `json:"warn_at,omitempty"`     // Share of a budget that triggers a warning (default 0.8)
+}
+
+// HistorySettings controls the local record of requests made with reign
+type HistorySettings struct {
+	Disabled bool   `json:"disabled,omitempty"`  // Don't record requests at all
+	Content  string `json:"content,omitempty"`   // "full" (default), "truncate" or "none"
+	MaxChars int    `json:"max_chars,omitempty"` // Characters kept per message with "truncate" (default 200)
+}
+
+// TracingSettings enables trace export. Environment variables take precedence.
+type TracingSettings struct {
+	Endpoint string `json:"endpoint,omitempty"` // OTLP/HTTP collector, e.g. http://localhost:4318
+	File     string `json:"file,omitempty"`     // Append OTLP/JSON lines to this file instead
+}
+
+// Layout customizes the developer and operator dashboards
+type Layout struct {
+	Developer DashboardLayout `json:"developer,omitempty"`
+	Operator  DashboardLayout `json:"operator,omitempty"`
+}
+
+// DashboardLayout customizes one dashboard. Unset fields keep the defaults.
+type DashboardLayout struct {
+	Sections     []string `json:"sections,omitempty"`      // Section IDs in display order; unlisted sections are hidden
+	Hide         []string `json:"hide,omitempty"`          // Section IDs to hide from the default order
+	ModelColumns []string `json:"model_columns,omitempty"` // Columns of the models table, in order
+	QuickActions []string `json:"quick_actions"`           // Replaces the default quick actions; [] hides them
+}
+
+// ThemeColors defines a custom theme. Colors are ANSI numbers ("205") or hex
+// values ("#ff5fd7"); empty fields are inherited from the base theme.
+type ThemeColors struct {
+	Base     string `json:"base,omitempty"` // Built-in theme to inherit from (default "dark")
+	Primary  string `json:"primary,omitempty"`
+	Accent   string `json:"accent,omitempty"`
+	Label    string `json:"label,omitempty"`
+	Value    string `json:"value,omitempty"`
+	Muted    string `json:"muted,omitempty"`
+	Border   string `json:"border,omitempty"`
+	HeaderBg string `json:"header_bg,omitempty"`
+	Success  string `json:"success,omitempty"`
+	Warning  string `json:"warning,omitempty"`
+	Error    string `json:"error,omitempty"`
+	Info     string `json:"info,omitempty"`
+}
+
+// Home returns the sovereyn home directory ($SOVEREYN_HOME or ~/.sovereyn)
+func Home() string {
+	if home := os.Getenv("SOVEREYN_HOME"); home != "" {
+		return home
+	}
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".sovereyn")
+}
+
+// SettingsPath returns the location of the settings file
+func SettingsPath() string {
+	return filepath.Join(Home(), "reign.json")
+}
+
+// LoadSettings reads the settings file. A missing file yields default settings.
+func LoadSettings() (*Settings, error) {
+	data, err := os.ReadFile(SettingsPath())
+	if os.IsNotExist(err) {
+		return &Settings{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read settings: %w", err)
+	}
+
+	var s Settings
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("invalid settings file %s: %w", SettingsPath(), err)
+	}
+	return &s, nil
+}
+
+// SaveSetting sets one top-level key of the settings file, leaving other
+// settings untouched. A nil value removes the key.
+func SaveSetting(key string, value any) error {
+	fields := make(map[string]json.RawMessage)
+	data, err := os.ReadFile(SettingsPath())
+	switch {
+	case err == nil:
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return fmt.Errorf("invalid settings file %s: %w", SettingsPath(), err)
+		}
+	case !os.IsNotExist(err):
+		return fmt.Errorf("failed to read settings: %w", err)
+	}
+
+	if value == nil {
+		delete(fields, key)
+	} else {
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("failed to encode %s setting: %w", key, err)
+		}
+		fields[key] = raw
+	}
+
+	data, err = json.MarshalIndent(fields, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode settings: %w", err)
+	}
+	if err := os.MkdirAll(Home(), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", Home(), err)
+	}
+	if err := os.WriteFile(SettingsPath(), append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write settings: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+// Package estimate predicts what a request will cost before it is sent.
+package estimate
+
+import (
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/sovereynai/reign/internal/client"
+	"github.com/sovereynai/reign/internal/history"
+)
+
+// Pricing sources, from most to least precise
+const (
+	SourceLocal       = "local"        // Runs on this machine, so free
+	SourceNodePricing = "node_pricing" // Prices published by the nodes hosting the model
+	SourceHistory     = "history"      // Cost per token of past requests
+	SourceDashboard   = "dashboard"    // Today's average cost per request
+	SourceUnknown     = "unknown"
+)
+
+// Output lengths assumed when there are no past responses to go by
+const (
+	defaultOutputLow  = 50
+	defaultOutputHigh = 500
+)
+
+// Range is an estimated low and high value
+type Range struct {
+	Low  float64 `json:"low"`
+	High float64 `json:"high"`
+}
+
+// Input is what the estimate is made from. Everything but the request is
+// optional.
+type Input struct {
+	Request     client.ChatRequest
+	LocalModels []string                 // Models installed on this machine
+	Locations   []map[string]interface{} // Where the model runs, from LocateModel
+	Stats       *client.DashboardStats
+	History     []history.Entry // Recent requests, for output lengths and costs
+}
+
+// Estimate is the predicted size, cost and wait of a request
+type Estimate struct {
+	Model        string   `json:"model"`
+	PromptTokens int      `json:"prompt_tokens"`
+	OutputTokens Range    `json:"output_tokens"`
+	Cost         Range    `json:"cost"` // Credits
+	Source       string   `json:"pricing_source"`
+	Nodes        int      `json:"nodes"` // Remote nodes hosting the model
+	QueueWaitSec float64  `json:"queue_wait_sec"`
+	Notes        []string `json:"notes,omitempty"`
+}
+
+// Tokens approximates the number of tokens in text at about four
+// characters per token
+func Tokens(text string) int {
+	return (len([]rune(text)) + 3) / 4
+}
+
+// PromptTokens approximates the tokens of a chat request's messages,
+// including a small overhead per message
+func PromptTokens(messages []client.ChatMessage) int {
+	n := 0
+	for _, m := range messages {
+		n += Tokens(m.Content) + 4
+	}
+	return n
+}
+
+// Make estimates a request
+func Make(in Input) Estimate {
+	req := in.Request
+	output, sampled := outputTokens(in)
+	e := Estimate{
+		Model:        req.Model,
+		PromptTokens: PromptTokens(req.Messages),
+		OutputTokens: output,
+		Source:       SourceUnknown,
+	}
+	if req.Options != nil && req.Options.MaxTokens > 0 {
+		e.OutputTokens.Low = min(e.OutputTokens.Low, float64(req.Options.MaxTokens))
+		e.OutputTokens.High = min(e.OutputTokens.High, float64(req.Options.MaxTokens))
+	}
+	tokens := Range{
+		Low:  float64(e.PromptTokens) + e.OutputTokens.Low,
+		High: float64(e.PromptTokens) + e.OutputTokens.High,
+	}
+
+	var prices []float64 // Credits per 1000 tokens, per node
+	local := slices.Contains(in.LocalModels, req.Model)
+	for _, loc := range in.Locations {
+		if t, _ := loc["type"].(string); t == "local" {
+			local = true
+			continue
+		}
+		e.Nodes++
+		if p, ok := loc["price_per_1k_tokens"].(float64); ok && p >= 0 {
+			prices = append(prices, p)
+		}
+	}
+
+	switch {
+	case local:
+		e.Source = SourceLocal
+		e.Notes = append(e.Notes, "Installed locally, so it runs on this machine for free unless it's busy")
+		return e
+	case len(prices) > 0:
+		e.Source = SourceNodePricing
+		e.Cost = Range{
+			Low:  tokens.Low / 1000 * slices.Min(prices),
+			High: tokens.High / 1000 * slices.Max(prices),
+		}
+		if len(prices) < e.Nodes {
+			e.Notes = append(e.Notes, fmt.Sprintf("%d of %d nodes publish prices", len(prices), e.Nodes))
+		}
+	default:
+		if perToken, ok := historyCostPerToken(in.History, req.Model); ok {
+			e.Source = SourceHistory
+			e.Cost = Range{Low: tokens.Low * perToken.Low, High: tokens.High * perToken.High}
+		} else if perRequest, ok := dashboardCostPerRequest(in.Stats, req.Model); ok {
+			e.Source = SourceDashboard
+			e.Cost = Range{Low: perRequest * 0.5, High: perRequest * 1.5}
+			e.Notes = append(e.Notes, "Based on today's average request; prompt length isn't taken into account")
+		} else {
+			e.Notes = append(e.Notes, "No pricing or past requests for this model, so the cost can't be estimated")
+		}
+	}
+
+	if in.Stats != nil {
+		e.QueueWaitSec = in.Stats.Network.EstWaitSec
+	}
+	if e.Nodes == 0 && in.Locations != nil {
+		e.Notes = append(e.Notes, "No nodes currently host this model")
+	}
+	if !sampled {
+		e.Notes = append(e.Notes, fmt.Sprintf("Output length assumed to be %d-%d tokens", defaultOutputLow, defaultOutputHigh))
+	}
+	return e
+}
+
+// outputTokens estimates the response length from past responses of the
+// same model: the middle half of them, or all if there are few. It reports
+// whether there were any to go by.
+func outputTokens(in Input) (Range, bool) {
+	var lengths []float64
+	for _, e := range in.History {
+		if e.Model == in.Request.Model && e.Error == "" && e.Content == "" && e.Response != "" {
+			lengths = append(lengths, float64(Tokens(e.Response)))
+		}
+	}
+	if len(lengths) == 0 {
+		return Range{Low: defaultOutputLow, High: defaultOutputHigh}, false
+	}
+	return spread(lengths), true
+}
+
+// historyCostPerToken is the spread of cost per token of past paid
+// requests to the model whose full content was kept
+func historyCostPerToken(entries []history.Entry, model string) (Range, bool) {
+	var rates []float64
+	for _, e := range entries {
+		if e.Model != model || e.Cost <= 0 || e.Content != "" {
+			continue
+		}
+		if tokens := PromptTokens(e.Messages) + Tokens(e.Response); tokens > 0 {
+			rates = append(rates, e.Cost/float64(tokens))
+		}
+	}
+	if len(rates) == 0 {
+		return Range{}, false
+	}
+	return spread(rates), true
+}
+
+func dashboardCostPerRequest(stats *client.DashboardStats, model string) (float64, bool) {
+	if stats == nil || stats.Developer == nil {
+		return 0, false
+	}
+	for _, m := range stats.Developer.Models {
+		if m.Name == model && m.RequestsToday > 0 && m.CreditsSpent > 0 {
+			return m.CreditsSpent / float64(m.RequestsToday), true
+		}
+	}
+	return 0, false
+}
+
+// spread returns the interquartile range of values, or their full range when
+// there are fewer than four
+func spread(values []float64) Range {
+	slices.Sort(values)
+	if len(values) < 4 {
+		return Range{Low: values[0], High: values[len(values)-1]}
+	}
+	q := func(p float64) float64 {
+		return values[int(math.Round(p*float64(len(values)-1)))]
+	}
+	return Range{Low: q(0.25), High: q(0.75)}
+}
@@ -0,0 +1,220 @@
+package exporter
+
+import (
+	"context"
+	"maps"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/sovereynai/reign/internal/client"
+)
+
+// Exporter polls throne for dashboard stats and serves the latest ones in
+// the Prometheus text exposition format
+type Exporter struct {
+	client   *client.ThroneClient
+	interval time.Duration
+
+	mu         sync.Mutex
+	stats      *client.DashboardStats
+	lastErr    error
+	lastScrape time.Time
+	duration   time.Duration
+	failures   int
+}
+
+// New creates an exporter that refreshes stats every interval
+func New(c *client.ThroneClient, interval time.Duration) *Exporter {
+	return &Exporter{client: c, interval: interval}
+}
+
+// Run polls throne until ctx is cancelled
+func (e *Exporter) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		e.Refresh()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Refresh fetches stats from throne once. Failed fetches keep the previous
+// stats but mark throne as down.
+func (e *Exporter) Refresh() error {
+	start := time.Now()
+	stats, err := e.client.GetDashboardStats()
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.lastScrape = start
+	e.duration = time.Since(start)
+	e.lastErr = err
+	if err != nil {
+		e.failures++
+		return err
+	}
+	e.stats = stats
+	return nil
+}
+
+// ServeHTTP writes the current metrics
+func (e *Exporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	e.mu.Lock()
+	families := e.collect()
+	e.mu.Unlock()
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	writeFamilies(w, families)
+}
+
+// collect builds metric families from the latest stats. Callers hold mu.
+func (e *Exporter) collect() []*family {
+	up := 1.0
+	if e.lastErr != nil || e.stats == nil {
+		up = 0
+	}
+
+	families := []*family{
+		gauge("sovereyn_up", "Whether the last stats fetch from throne succeeded").add(up),
+		gauge("sovereyn_scrape_duration_seconds", "Time taken by the last stats fetch").add(e.duration.Seconds()),
+		counter("sovereyn_scrape_failures_total", "Stats fetches from throne that failed").add(float64(e.failures)),
+	}
+	if !e.lastScrape.IsZero() {
+		families = append(families,
+			gauge("sovereyn_last_scrape_timestamp_seconds", "Unix time of the last stats fetch").add(float64(e.lastScrape.Unix())))
+	}
+	if e.stats == nil {
+		return families
+	}
+
+	s := e.stats
+	families = append(families,
+		gauge("sovereyn_info", "Throne version and node role").add(1, "version", s.Version.Version, "role", s.Role),
+	)
+	if s.Developer != nil {
+		families = append(families, developerFamilies(s.Developer)...)
+	}
+	if s.Operator != nil {
+		families = append(families, operatorFamilies(s.Operator)...)
+	}
+	return append(families, networkFamilies(&s.Network)...)
+}
+
+func developerFamilies(d *client.DeveloperStats) []*family {
+	latency := gauge("sovereyn_inference_latency_seconds", "Inference latency percentiles")
+	latency.add(ms(d.Performance.P50LatencyMs), "quantile", "0.5")
+	latency.add(ms(d.Performance.P95LatencyMs), "quantile", "0.95")
+	latency.add(ms(d.Performance.P99LatencyMs), "quantile", "0.99")
+
+	modelRequests := gauge("sovereyn_model_requests_today", "Inference requests made today per model")
+	modelLatency := gauge("sovereyn_model_latency_avg_seconds", "Average inference latency per model")
+	modelCredits := gauge("sovereyn_model_credits_spent", "Credits spent per model")
+	for _, m := range d.Models {
+		modelRequests.add(float64(m.RequestsToday), "model", m.Name)
+		modelLatency.add(ms(m.AvgLatencyMs), "model", m.Name)
+		modelCredits.add(m.CreditsSpent, "model", m.Name)
+	}
+
+	return []*family{
+		gauge("sovereyn_credits_balance", "Credit balance").add(d.Credits.Balance),
+		gauge("sovereyn_credits_spent_today", "Credits spent today").add(d.Credits.TodaySpent),
+		gauge("sovereyn_credits_burn_rate_per_day", "Credits spent per day at the current rate").add(d.Credits.BurnRate),
+		gauge("sovereyn_credits_runway_days", "Days of credit left at the current rate").add(float64(d.Credits.RunwayDays)),
+		gauge("sovereyn_inference_requests_today", "Inference requests made today").add(float64(d.Inference.Today)),
+		counter("sovereyn_inference_requests_total", "Inference requests made in total").add(float64(d.Inference.Total)),
+		gauge("sovereyn_inference_success_ratio", "Share of inference requests that succeeded").add(d.Inference.SuccessRate / 100),
+		gauge("sovereyn_inference_latency_avg_seconds", "Average inference latency").add(ms(d.Performance.AvgLatencyMs)),
+		latency,
+		gauge("sovereyn_inference_local_ratio", "Share of requests served locally").add(d.Performance.LocalPercent / 100),
+		modelRequests,
+		modelLatency,
+		modelCredits,
+	}
+}
+
+func operatorFamilies(o *client.OperatorStats) []*family {
+	earnings := gauge("sovereyn_earnings_credits", "Credits earned")
+	earnings.add(o.Earnings.Today, "period", "today")
+	earnings.add(o.Earnings.ThisWeek, "period", "week")
+	earnings.add(o.Earnings.AllTime, "period", "all_time")
+
+	breakdown := gauge("sovereyn_earnings_today_credits", "Credits earned today by source")
+	breakdown.add(o.Earnings.Breakdown.Inference, "source", "inference")
+	breakdown.add(o.Earnings.Breakdown.Bandwidth, "source", "bandwidth")
+	breakdown.add(o.Earnings.Breakdown.Storage, "source", "storage")
+	breakdown.add(o.Earnings.Breakdown.Bonus, "source", "bonus")
+
+	resources := gauge("sovereyn_resource_usage_ratio", "Hardware utilization")
+	resources.add(o.Hardware.GPU.Percent/100, "resource", "gpu")
+	resources.add(o.Hardware.CPU.Percent/100, "resource", "cpu")
+	resources.add(o.Hardware.RAM.Percent/100, "resource", "ram")
+	resources.add(o.Hardware.Disk.Percent/100, "resource", "disk")
+
+	temperature := gauge("sovereyn_temperature_celsius", "Hardware temperature")
+	temperature.add(o.Hardware.Temperature.GPU, "resource", "gpu")
+	temperature.add(o.Hardware.Temperature.CPU, "resource", "cpu")
+
+	modelRequests := gauge("sovereyn_served_model_requests", "Requests served in the last 24h per model")
+	modelLatency := gauge("sovereyn_served_model_latency_avg_seconds", "Average serving latency per model")
+	modelRevenue := gauge("sovereyn_served_model_revenue_credits", "Credits earned per model")
+	for _, m := range o.ModelsServed {
+		modelRequests.add(float64(m.Requests), "model", m.Name, "status", m.Status)
+		modelLatency.add(ms(m.AvgLatencyMs), "model", m.Name)
+		modelRevenue.add(m.Revenue, "model", m.Name)
+	}
+
+	// Report every level so alerting rules see zeros rather than gaps
+	alerts := gauge("sovereyn_alerts", "Active alerts by level")
+	counts := map[string]int{"info": 0, "warning": 0, "error": 0}
+	for _, a := range o.Alerts {
+		counts[a.Level]++
+	}
+	for _, level := range slices.Sorted(maps.Keys(counts)) {
+		alerts.add(float64(counts[level]), "level", level)
+	}
+
+	return []*family{
+		earnings,
+		gauge("sovereyn_earnings_pending_credits", "Credits pending settlement").add(o.Earnings.Pending),
+		gauge("sovereyn_earnings_held_credits", "Credits held in escrow").add(o.Earnings.Held),
+		breakdown,
+		gauge("sovereyn_node_rank", "Node rank by earnings").add(float64(o.Earnings.Rank)),
+		gauge("sovereyn_nodes", "Nodes in the network").add(float64(o.Earnings.TotalNodes)),
+		gauge("sovereyn_served_requests_24h", "Requests served in the last 24h").add(float64(o.Workload.RequestsServed)),
+		gauge("sovereyn_served_failures_24h", "Requests that failed in the last 24h").add(float64(o.Workload.Failures)),
+		gauge("sovereyn_served_success_ratio", "Share of served requests that succeeded").add(o.Workload.SuccessRate / 100),
+		gauge("sovereyn_served_latency_avg_seconds", "Average serving latency").add(ms(o.Workload.AvgLatencyMs)),
+		modelRequests,
+		modelLatency,
+		modelRevenue,
+		resources,
+		temperature,
+		gauge("sovereyn_power_watts", "Power draw").add(o.Hardware.PowerWatts),
+		gauge("sovereyn_reputation_score", "Reputation score").add(o.Reputation.Score),
+		gauge("sovereyn_reputation_max_score", "Highest possible reputation score").add(o.Reputation.MaxScore),
+		gauge("sovereyn_reputation_ratings", "Ratings behind the reputation score").add(float64(o.Reputation.Ratings)),
+		gauge("sovereyn_uptime_streak_days", "Consecutive days online").add(float64(o.Reputation.UptimeStreak)),
+		alerts,
+	}
+}
+
+func networkFamilies(n *client.NetworkStats) []*family {
+	return []*family{
+		gauge("sovereyn_network_peers", "Peers connected").add(float64(n.PeersConnected)),
+		gauge("sovereyn_network_models_available", "Models available on the network").add(float64(n.ModelsAvailable)),
+		gauge("sovereyn_network_queue_depth", "Jobs queued on the network").add(float64(n.QueueDepth)),
+		gauge("sovereyn_network_est_wait_seconds", "Estimated queue wait").add(n.EstWaitSec),
+		gauge("sovereyn_network_data_relayed_bytes", "Data relayed for the network").add(n.DataRelayedGB * 1e9),
+	}
+}
+
+func ms(v int) float64 {
+	return float64(v) / 1000
+}
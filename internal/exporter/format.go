@@ -0,0 +1,80 @@
+package exporter
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// family is a named metric with its samples
+type family struct {
+	name    string
+	help    string
+	kind    string // "gauge" or "counter"
+	samples []sample
+}
+
+type sample struct {
+	labels []string // Name, value pairs
+	value  float64
+}
+
+func gauge(name, help string) *family {
+	return &family{name: name, help: help, kind: "gauge"}
+}
+
+func counter(name, help string) *family {
+	return &family{name: name, help: help, kind: "counter"}
+}
+
+// add appends a sample with labels given as name, value pairs
+func (f *family) add(value float64, labels ...string) *family {
+	f.samples = append(f.samples, sample{labels: labels, value: value})
+	return f
+}
+
+// writeFamilies writes families in the Prometheus text exposition format,
+// skipping families without samples
+func writeFamilies(w io.Writer, families []*family) {
+	for _, f := range families {
+		if len(f.samples) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "# HELP %s %s\n", f.name, f.help)
+		fmt.Fprintf(w, "# TYPE %s %s\n", f.name, f.kind)
+		for _, s := range f.samples {
+			fmt.Fprintf(w, "%s%s %s\n", f.name, formatLabels(s.labels), formatValue(s.value))
+		}
+	}
+}
+
+func formatLabels(labels []string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	pairs := make([]string, 0, len(labels)/2)
+	for i := 0; i+1 < len(labels); i += 2 {
+		pairs = append(pairs, fmt.Sprintf("%s=\"%s\"", labels[i], escapeLabel(labels[i+1])))
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabel(v string) string {
+	return labelEscaper.Replace(v)
+}
+
+func formatValue(v float64) string {
+	switch {
+	case math.IsNaN(v):
+		return "NaN"
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
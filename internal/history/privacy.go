@@ -0,0 +1,96 @@
+package history
+
+import (
+	"fmt"
+
+	"github.com/sovereynai/reign/internal/client"
+	"github.com/sovereynai/reign/internal/config"
+)
+
+// Content modes for the history privacy setting
+const (
+	ContentFull     = "full"
+	ContentTruncate = "truncate"
+	ContentNone     = "none"
+)
+
+// defaultMaxChars is how much of each message is kept when truncating
+const defaultMaxChars = 200
+
+// ApplyPrivacy limits the prompt and response content kept in e, including
+// tool call arguments, according to the user's history settings
+func ApplyPrivacy(e *Entry, s config.HistorySettings) error {
+	switch s.Content {
+	case "", ContentFull:
+		return nil
+	case ContentTruncate:
+		limit := s.MaxChars
+		if limit <= 0 {
+			limit = defaultMaxChars
+		}
+		changed := false
+		for i := range e.Messages {
+			changed = truncate(&e.Messages[i].Content, limit) || changed
+			var argsChanged bool
+			e.Messages[i].ToolCalls, argsChanged = limitArguments(e.Messages[i].ToolCalls, limit)
+			changed = argsChanged || changed
+		}
+		changed = truncate(&e.Response, limit) || changed
+		if changed {
+			e.Content = "truncated"
+		}
+		return nil
+	case ContentNone:
+		for i := range e.Messages {
+			e.Messages[i].Content = ""
+			e.Messages[i].ToolCalls, _ = limitArguments(e.Messages[i].ToolCalls, 0)
+		}
+		delete(e.Params, "tools")
+		e.Response = ""
+		e.Content = "omitted"
+		return nil
+	}
+	return fmt.Errorf("invalid history content setting %q (use %s, %s or %s)", s.Content, ContentFull, ContentTruncate, ContentNone)
+}
+
+func truncate(s *string, limit int) bool {
+	r := []rune(*s)
+	if len(r) <= limit {
+		return false
+	}
+	*s = string(r[:limit]) + "..."
+	return true
+}
+
+// limitArguments returns a copy of calls with their string arguments
+// truncated to limit, or with no arguments when limit is 0, and whether
+// anything was cut. The calls themselves are shared with the request, so
+// they are left alone.
+func limitArguments(calls []client.ToolCall, limit int) ([]client.ToolCall, bool) {
+	if len(calls) == 0 {
+		return calls, false
+	}
+	out := make([]client.ToolCall, len(calls))
+	changed := false
+	for i, call := range calls {
+		out[i] = call
+		if len(call.Function.Arguments) == 0 {
+			continue
+		}
+		if limit == 0 {
+			out[i].Function.Arguments = nil
+			changed = true
+			continue
+		}
+		args := make(map[string]any, len(call.Function.Arguments))
+		for k, v := range call.Function.Arguments {
+			if s, ok := v.(string); ok && truncate(&s, limit) {
+				v = s
+				changed = true
+			}
+			args[k] = v
+		}
+		out[i].Function.Arguments = args
+	}
+	return out, changed
+}
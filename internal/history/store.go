@@ -0,0 +1,445 @@
+// Package history keeps a local record of inference requests made through
+// reign so they can be listed, searched and replayed later.
+package history
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/sovereynai/reign/internal/client"
+	"github.com/sovereynai/reign/internal/config"
+)
+
+// Request kinds
+const (
+	KindChat      = "chat"
+	KindVision    = "vision"
+	KindEmbedding = "embedding"
+)
+
+// Entry is one recorded request and its outcome
+type Entry struct {
+	ID        string               `json:"id"`
+	Time      time.Time            `json:"time"`
+	Kind      string               `json:"kind"`
+	Model     string               `json:"model"`
+	Messages  []client.ChatMessage `json:"messages,omitempty"`
+	Params    map[string]any       `json:"params,omitempty"` // Request fields other than model and messages
+	Response  string               `json:"response,omitempty"`
+	Error     string               `json:"error,omitempty"`
+	NodeID    string               `json:"node_id,omitempty"`
+	LatencyMs int64                `json:"latency_ms"`
+	Cost      float64              `json:"cost,omitempty"`
+	Content   string               `json:"content,omitempty"` // "truncated" or "omitted" when limited by privacy settings
+}
+
+// Prompt returns the content of the last user message
+func (e *Entry) Prompt() string {
+	for i := len(e.Messages) - 1; i >= 0; i-- {
+		if e.Messages[i].Role == "user" {
+			return e.Messages[i].Content
+		}
+	}
+	return ""
+}
+
+// Summary is the index record of an entry, enough to list and filter
+// without reading request content
+type Summary struct {
+	ID        string    `json:"id"`
+	Time      time.Time `json:"time"`
+	Kind      string    `json:"kind"`
+	Model     string    `json:"model"`
+	NodeID    string    `json:"node_id,omitempty"`
+	LatencyMs int64     `json:"latency_ms"`
+	Cost      float64   `json:"cost,omitempty"`
+	Failed    bool      `json:"failed,omitempty"`
+	Preview   string    `json:"preview,omitempty"` // Start of the prompt
+	Offset    int64     `json:"offset"`            // Position of the entry in the entries file
+	Length    int       `json:"length"`
+}
+
+// Filter narrows down entries. Zero fields match everything.
+type Filter struct {
+	Model string // Substring of the model name
+	Since time.Time
+	Until time.Time
+	Limit int // Newest entries to return
+}
+
+func (f Filter) matches(s Summary) bool {
+	if f.Model != "" && !strings.Contains(strings.ToLower(s.Model), strings.ToLower(f.Model)) {
+		return false
+	}
+	if !f.Since.IsZero() && s.Time.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && !s.Time.Before(f.Until) {
+		return false
+	}
+	return true
+}
+
+// previewLength is how much of the prompt is kept in the index
+const previewLength = 80
+
+// Store keeps entries in an append-only JSON Lines file, with an index file
+// of summaries pointing into it
+type Store struct {
+	Dir string
+}
+
+// DefaultStore returns the store under the sovereyn home
+func DefaultStore() *Store {
+	return NewStore(filepath.Join(config.Home(), "history"))
+}
+
+// NewStore creates a store in the given directory
+func NewStore(dir string) *Store {
+	return &Store{Dir: dir}
+}
+
+func (s *Store) entriesPath() string { return filepath.Join(s.Dir, "entries.jsonl") }
+func (s *Store) indexPath() string   { return filepath.Join(s.Dir, "index.jsonl") }
+
+// Add records an entry, assigning an ID and time if they are unset
+func (s *Store) Add(e *Entry) error {
+	if e.ID == "" {
+		e.ID = newID()
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now().UTC()
+	}
+	line, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("failed to encode history entry: %w", err)
+	}
+
+	if err := os.MkdirAll(s.Dir, 0700); err != nil {
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+	f, err := os.OpenFile(s.entriesPath(), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open history: %w", err)
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to open history: %w", err)
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write history entry: %w", err)
+	}
+
+	return s.appendIndex(summarize(e, info.Size(), len(line)))
+}
+
+// List returns summaries matching f, newest first
+func (s *Store) List(f Filter) ([]Summary, error) {
+	index, err := s.loadIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	var out []Summary
+	for i := len(index) - 1; i >= 0; i-- {
+		if !f.matches(index[i]) {
+			continue
+		}
+		out = append(out, index[i])
+		if f.Limit > 0 && len(out) == f.Limit {
+			break
+		}
+	}
+	return out, nil
+}
+
+// Get returns the entry whose ID is id or starts with it
+func (s *Store) Get(id string) (*Entry, error) {
+	summary, err := s.resolve(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Read straight from the offset; fall back to a scan if the index is stale
+	f, err := os.Open(s.entriesPath())
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history: %w", err)
+	}
+	defer f.Close()
+	line := make([]byte, summary.Length)
+	if _, err := f.ReadAt(line, summary.Offset); err == nil {
+		var e Entry
+		if json.Unmarshal(line, &e) == nil && e.ID == summary.ID {
+			return &e, nil
+		}
+	}
+
+	var found *Entry
+	err = s.scan(func(e *Entry, _ int64, _ int) bool {
+		if e.ID == summary.ID {
+			found = e
+			return false
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if found == nil {
+		return nil, fmt.Errorf("history entry %s not found", id)
+	}
+	return found, nil
+}
+
+// Entries returns the full entries matching f, newest first
+func (s *Store) Entries(f Filter) ([]Entry, error) {
+	return s.Search("", f)
+}
+
+// Search returns entries matching f whose prompt, messages or response
+// contain query (case-insensitive), newest first
+func (s *Store) Search(query string, f Filter) ([]Entry, error) {
+	query = strings.ToLower(query)
+	var out []Entry
+	err := s.scan(func(e *Entry, _ int64, _ int) bool {
+		if f.matches(summarize(e, 0, 0)) && (query == "" || e.contains(query)) {
+			out = append(out, *e)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.Reverse(out)
+	if f.Limit > 0 && len(out) > f.Limit {
+		out = out[:f.Limit]
+	}
+	return out, nil
+}
+
+func (e *Entry) contains(query string) bool {
+	if strings.Contains(strings.ToLower(e.Response), query) {
+		return true
+	}
+	for _, m := range e.Messages {
+		if strings.Contains(strings.ToLower(m.Content), query) {
+			return true
+		}
+	}
+	return false
+}
+
+// Remove deletes every entry for which match returns true and returns how
+// many were removed. The files are rewritten so the content is gone.
+func (s *Store) Remove(match func(Summary) bool) (int, error) {
+	var kept bytes.Buffer
+	var index []Summary
+	removed := 0
+	err := s.scan(func(e *Entry, _ int64, _ int) bool {
+		line, err := json.Marshal(e)
+		if err != nil {
+			return true
+		}
+		if match(summarize(e, 0, 0)) {
+			removed++
+			return true
+		}
+		index = append(index, summarize(e, int64(kept.Len()), len(line)))
+		kept.Write(append(line, '\n'))
+		return true
+	})
+	if err != nil || removed == 0 {
+		return 0, err
+	}
+
+	if err := writeFileAtomic(s.entriesPath(), kept.Bytes()); err != nil {
+		return 0, fmt.Errorf("failed to rewrite history: %w", err)
+	}
+	if err := s.writeIndex(index); err != nil {
+		return 0, err
+	}
+	return removed, nil
+}
+
+// RemoveIDs deletes the entries with the given IDs or ID prefixes
+func (s *Store) RemoveIDs(ids []string) (int, error) {
+	resolved := make(map[string]bool)
+	for _, id := range ids {
+		summary, err := s.resolve(id)
+		if err != nil {
+			return 0, err
+		}
+		resolved[summary.ID] = true
+	}
+	return s.Remove(func(sum Summary) bool { return resolved[sum.ID] })
+}
+
+// resolve finds the single index record matching an ID or ID prefix
+func (s *Store) resolve(id string) (Summary, error) {
+	index, err := s.loadIndex()
+	if err != nil {
+		return Summary{}, err
+	}
+
+	var matches []Summary
+	for _, sum := range index {
+		if sum.ID == id {
+			return sum, nil
+		}
+		if strings.HasPrefix(sum.ID, id) {
+			matches = append(matches, sum)
+		}
+	}
+	switch {
+	case id == "" || len(matches) == 0:
+		return Summary{}, fmt.Errorf("history entry %q not found", id)
+	case len(matches) > 1:
+		return Summary{}, fmt.Errorf("history entry %q is ambiguous (%d matches)", id, len(matches))
+	}
+	return matches[0], nil
+}
+
+// scan calls fn with each readable entry, oldest first, until fn returns false
+func (s *Store) scan(fn func(e *Entry, offset int64, length int) bool) error {
+	f, err := os.Open(s.entriesPath())
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open history: %w", err)
+	}
+	defer f.Close()
+
+	reader := bufio.NewReaderSize(f, 64*1024)
+	var offset int64
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			length := len(bytes.TrimRight(line, "\n"))
+			var e Entry
+			// Lines that cannot be decoded (e.g. a partial write) are skipped
+			if json.Unmarshal(line, &e) == nil && e.ID != "" {
+				if !fn(&e, offset, length) {
+					return nil
+				}
+			}
+			offset += int64(len(line))
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read history: %w", err)
+		}
+	}
+}
+
+// loadIndex reads the index, rebuilding it from the entries if it is missing
+func (s *Store) loadIndex() ([]Summary, error) {
+	data, err := os.ReadFile(s.indexPath())
+	if os.IsNotExist(err) {
+		return s.rebuildIndex()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history index: %w", err)
+	}
+
+	var index []Summary
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		var sum Summary
+		if json.Unmarshal(line, &sum) == nil && sum.ID != "" {
+			index = append(index, sum)
+		}
+	}
+	return index, nil
+}
+
+func (s *Store) rebuildIndex() ([]Summary, error) {
+	var index []Summary
+	err := s.scan(func(e *Entry, offset int64, length int) bool {
+		index = append(index, summarize(e, offset, length))
+		return true
+	})
+	if err != nil || len(index) == 0 {
+		return nil, err
+	}
+	return index, s.writeIndex(index)
+}
+
+func (s *Store) appendIndex(sum Summary) error {
+	line, err := json.Marshal(sum)
+	if err != nil {
+		return fmt.Errorf("failed to encode history index: %w", err)
+	}
+	f, err := os.OpenFile(s.indexPath(), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open history index: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write history index: %w", err)
+	}
+	return nil
+}
+
+func (s *Store) writeIndex(index []Summary) error {
+	var buf bytes.Buffer
+	for _, sum := range index {
+		line, err := json.Marshal(sum)
+		if err != nil {
+			return fmt.Errorf("failed to encode history index: %w", err)
+		}
+		buf.Write(append(line, '\n'))
+	}
+	if err := writeFileAtomic(s.indexPath(), buf.Bytes()); err != nil {
+		return fmt.Errorf("failed to write history index: %w", err)
+	}
+	return nil
+}
+
+func summarize(e *Entry, offset int64, length int) Summary {
+	preview := strings.Join(strings.Fields(e.Prompt()), " ")
+	if r := []rune(preview); len(r) > previewLength {
+		preview = string(r[:previewLength-3]) + "..."
+	}
+	return Summary{
+		ID:        e.ID,
+		Time:      e.Time,
+		Kind:      e.Kind,
+		Model:     e.Model,
+		NodeID:    e.NodeID,
+		LatencyMs: e.LatencyMs,
+		Cost:      e.Cost,
+		Failed:    e.Error != "",
+		Preview:   preview,
+		Offset:    offset,
+		Length:    length,
+	}
+}
+
+// writeFileAtomic replaces path with data via a temporary file
+func writeFileAtomic(path string, data []byte) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func newID() string {
+	var b [6]byte
+	rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
@@ -0,0 +1,238 @@
+// Package mcp serves tools over the Model Context Protocol, as JSON-RPC
+// messages on stdio, so agents and editors can call them.
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+)
+
+// Protocol versions this server speaks, newest first
+var protocolVersions = []string{"2025-06-18", "2025-03-26", "2024-11-05"}
+
+// JSON-RPC error codes
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+)
+
+// Tool is a tool the server offers
+type Tool struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	InputSchema map[string]any `json:"inputSchema"` // JSON Schema of the arguments
+
+	// Call runs the tool. Its result is returned to the client as text; an
+	// error is returned as a failed tool result, not a protocol error.
+	Call func(ctx context.Context, args json.RawMessage) (string, error) `json:"-"`
+}
+
+// Server answers MCP requests
+type Server struct {
+	Name    string
+	Version string
+	Tools   []Tool
+
+	mu       sync.Mutex // Serializes writes and guards inFlight
+	out      io.Writer
+	inFlight map[string]context.CancelFunc // Tool calls by request ID
+}
+
+type message struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type textContent struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type toolResult struct {
+	Content []textContent `json:"content"`
+	IsError bool          `json:"isError,omitempty"`
+}
+
+// Serve reads newline-delimited requests from in and writes responses to out
+// until in is closed or ctx is cancelled. Tool calls run concurrently.
+func (s *Server) Serve(ctx context.Context, in io.Reader, out io.Writer) error {
+	s.out = out
+	s.inFlight = make(map[string]context.CancelFunc)
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	lines := make(chan []byte)
+	readErr := make(chan error, 1)
+	go func() {
+		r := bufio.NewReader(in)
+		for {
+			line, err := r.ReadBytes('\n')
+			if len(bytes.TrimSpace(line)) > 0 {
+				select {
+				case lines <- line:
+				case <-ctx.Done():
+					return
+				}
+			}
+			if err != nil {
+				readErr <- err
+				return
+			}
+		}
+	}()
+
+	for {
+		var line []byte
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-readErr:
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("failed to read request: %w", err)
+		case line = <-lines:
+		}
+
+		var msg message
+		if err := json.Unmarshal(line, &msg); err != nil {
+			s.reply(nil, nil, &rpcError{Code: codeParseError, Message: "invalid JSON: " + err.Error()})
+			continue
+		}
+		if msg.Method == "" {
+			continue // A response to a request we never send
+		}
+		if msg.JSONRPC != "2.0" {
+			s.reply(msg.ID, nil, &rpcError{Code: codeInvalidRequest, Message: `jsonrpc must be "2.0"`})
+			continue
+		}
+
+		if msg.Method == "tools/call" && msg.ID != nil {
+			callCtx, callCancel := context.WithCancel(ctx)
+			s.mu.Lock()
+			s.inFlight[string(msg.ID)] = callCancel
+			s.mu.Unlock()
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				result, rpcErr := s.callTool(callCtx, msg.Params)
+				s.mu.Lock()
+				delete(s.inFlight, string(msg.ID))
+				s.mu.Unlock()
+				if callCtx.Err() == nil { // Cancelled calls get no response
+					s.reply(msg.ID, result, rpcErr)
+				}
+				callCancel()
+			}()
+			continue
+		}
+
+		result, rpcErr := s.handle(msg)
+		if msg.ID != nil {
+			s.reply(msg.ID, result, rpcErr)
+		}
+	}
+}
+
+// handle answers every request but tool calls, and notifications
+func (s *Server) handle(msg message) (any, *rpcError) {
+	switch msg.Method {
+	case "initialize":
+		var params struct {
+			ProtocolVersion string `json:"protocolVersion"`
+		}
+		json.Unmarshal(msg.Params, &params)
+		version := protocolVersions[0]
+		if slices.Contains(protocolVersions, params.ProtocolVersion) {
+			version = params.ProtocolVersion
+		}
+		return map[string]any{
+			"protocolVersion": version,
+			"capabilities":    map[string]any{"tools": map[string]any{}},
+			"serverInfo":      map[string]string{"name": s.Name, "version": s.Version},
+		}, nil
+	case "ping":
+		return map[string]any{}, nil
+	case "tools/list":
+		return map[string]any{"tools": s.Tools}, nil
+	case "notifications/cancelled":
+		var params struct {
+			RequestID json.RawMessage `json:"requestId"`
+		}
+		if json.Unmarshal(msg.Params, &params) == nil {
+			s.mu.Lock()
+			if cancel, ok := s.inFlight[string(params.RequestID)]; ok {
+				cancel()
+			}
+			s.mu.Unlock()
+		}
+		return nil, nil
+	}
+	if msg.ID == nil {
+		return nil, nil // Other notifications need no action
+	}
+	return nil, &rpcError{Code: codeMethodNotFound, Message: "method not found: " + msg.Method}
+}
+
+func (s *Server) callTool(ctx context.Context, rawParams json.RawMessage) (any, *rpcError) {
+	var params struct {
+		Name      string          `json:"name"`
+		Arguments json.RawMessage `json:"arguments"`
+	}
+	if err := json.Unmarshal(rawParams, &params); err != nil {
+		return nil, &rpcError{Code: codeInvalidParams, Message: "invalid params: " + err.Error()}
+	}
+	i := slices.IndexFunc(s.Tools, func(t Tool) bool { return t.Name == params.Name })
+	if i < 0 {
+		return nil, &rpcError{Code: codeInvalidParams, Message: "unknown tool: " + params.Name}
+	}
+	if len(params.Arguments) == 0 || string(params.Arguments) == "null" {
+		params.Arguments = json.RawMessage("{}")
+	}
+
+	text, err := s.Tools[i].Call(ctx, params.Arguments)
+	if err != nil {
+		return toolResult{Content: []textContent{{Type: "text", Text: err.Error()}}, IsError: true}, nil
+	}
+	return toolResult{Content: []textContent{{Type: "text", Text: text}}}, nil
+}
+
+func (s *Server) reply(id json.RawMessage, result any, rpcErr *rpcError) {
+	if id == nil {
+		id = json.RawMessage("null")
+	}
+	msg := message{JSONRPC: "2.0", ID: id, Result: result, Error: rpcErr}
+	if rpcErr == nil && result == nil {
+		msg.Result = map[string]any{}
+	}
+	data, err := json.Marshal(msg)
+	if err != nil {
+		data, _ = json.Marshal(message{JSONRPC: "2.0", ID: id, Error: &rpcError{Code: codeInvalidRequest, Message: err.Error()}})
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.out.Write(append(data, '\n'))
+}
@@ -0,0 +1,237 @@
+package mcp
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/sovereynai/reign/internal/client"
+)
+
+// DefaultModel is used by the chat tool when no model is given
+const DefaultModel = "llama3.2:3b"
+
+// Options are hooks around the requests tools send to throne
+type Options struct {
+	// Check can refuse a request before it is sent, e.g. when it would go
+	// over budget. Vision requests are checked with just their model.
+	// When the request is allowed, release is called once it is finished
+	// and recorded, so checks can account for the requests in flight.
+	Check func(req client.ChatRequest) (release func(), err error)
+
+	// OnChat is called after each chat request, whether or not it succeeded
+	OnChat func(req client.ChatRequest, resp *client.ChatResponse, err error, elapsed time.Duration)
+
+	// OnVision is called after each vision request
+	OnVision func(req client.VisionRequest, resp *client.VisionResponse, err error, elapsed time.Duration)
+}
+
+// Tools returns the tools backed by throne: chat, list_models,
+// locate_model, dashboard_stats and vision_classify
+func Tools(c *client.ThroneClient, opts Options) []Tool {
+	return []Tool{
+		{
+			Name:        "chat",
+			Description: "Send a prompt to a model on the Sovereyn network and return its reply. Use it to delegate sub-tasks to other models.",
+			InputSchema: objectSchema(map[string]any{
+				"prompt":      stringProperty("The prompt to send"),
+				"model":       stringProperty("Model to use (default " + DefaultModel + "); see list_models"),
+				"system":      stringProperty("System prompt"),
+				"temperature": map[string]any{"type": "number", "description": "Sampling temperature"},
+				"max_tokens":  map[string]any{"type": "integer", "description": "Maximum tokens to generate"},
+			}, "prompt"),
+			Call: func(ctx context.Context, raw json.RawMessage) (string, error) {
+				return chatTool(ctx, c, opts, raw)
+			},
+		},
+		{
+			Name:        "list_models",
+			Description: "List the models installed locally and those available across the Sovereyn network.",
+			InputSchema: objectSchema(map[string]any{}),
+			Call: func(ctx context.Context, raw json.RawMessage) (string, error) {
+				local, localErr := c.ListModels()
+				network, networkErr := c.ListNetworkModels()
+				if localErr != nil && networkErr != nil {
+					return "", localErr
+				}
+				names := []string{}
+				for _, m := range network {
+					names = append(names, m.Name)
+				}
+				if local == nil {
+					local = []string{}
+				}
+				return toJSON(map[string]any{"local": local, "network": names})
+			},
+		},
+		{
+			Name:        "locate_model",
+			Description: "Find which nodes host a model, with their latency and price where known.",
+			InputSchema: objectSchema(map[string]any{
+				"model": stringProperty("Model name, e.g. qwen2.5:7b"),
+			}, "model"),
+			Call: func(ctx context.Context, raw json.RawMessage) (string, error) {
+				var args struct {
+					Model string `json:"model"`
+				}
+				if err := json.Unmarshal(raw, &args); err != nil || args.Model == "" {
+					return "", errors.New("model is required")
+				}
+				locations, err := c.LocateModel(args.Model)
+				if err != nil {
+					return "", err
+				}
+				return toJSON(map[string]any{"model": args.Model, "locations": locations})
+			},
+		},
+		{
+			Name:        "dashboard_stats",
+			Description: "Get the current dashboard: credits, spending, network health, queue depth and, on nodes, earnings and hardware use.",
+			InputSchema: objectSchema(map[string]any{}),
+			Call: func(ctx context.Context, raw json.RawMessage) (string, error) {
+				stats, err := c.GetDashboardStats()
+				if err != nil {
+					return "", err
+				}
+				return toJSON(stats)
+			},
+		},
+		{
+			Name:        "vision_classify",
+			Description: "Classify an image with a vision model on the Sovereyn network and return the most likely labels.",
+			InputSchema: objectSchema(map[string]any{
+				"model":        stringProperty("Vision model to use"),
+				"image_path":   stringProperty("Path of an image file on this machine"),
+				"image_base64": stringProperty("The image file, base64-encoded, instead of image_path"),
+				"top_k":        map[string]any{"type": "integer", "description": "Number of labels to return (default 5)"},
+			}, "model"),
+			Call: func(ctx context.Context, raw json.RawMessage) (string, error) {
+				return visionTool(ctx, c, opts, raw)
+			},
+		},
+	}
+}
+
+func chatTool(ctx context.Context, c *client.ThroneClient, opts Options, raw json.RawMessage) (string, error) {
+	var args struct {
+		Prompt      string   `json:"prompt"`
+		Model       string   `json:"model"`
+		System      string   `json:"system"`
+		Temperature *float64 `json:"temperature"`
+		MaxTokens   int      `json:"max_tokens"`
+	}
+	if err := json.Unmarshal(raw, &args); err != nil {
+		return "", fmt.Errorf("invalid arguments: %w", err)
+	}
+	if args.Prompt == "" {
+		return "", errors.New("prompt is required")
+	}
+
+	req := client.ChatRequest{Model: args.Model}
+	if req.Model == "" {
+		req.Model = DefaultModel
+	}
+	if args.System != "" {
+		req.Messages = append(req.Messages, client.ChatMessage{Role: "system", Content: args.System})
+	}
+	req.Messages = append(req.Messages, client.ChatMessage{Role: "user", Content: args.Prompt})
+	if args.Temperature != nil || args.MaxTokens > 0 {
+		req.Options = &client.ChatOptions{Temperature: args.Temperature, MaxTokens: args.MaxTokens}
+	}
+
+	if opts.Check != nil {
+		release, err := opts.Check(req)
+		if err != nil {
+			return "", err
+		}
+		defer release()
+	}
+	start := time.Now()
+	resp, err := c.SendChatContext(ctx, req)
+	if err == nil && !resp.Success {
+		err = errors.New("inference failed")
+		if resp.Error != "" {
+			err = errors.New(resp.Error)
+		}
+	}
+	if opts.OnChat != nil && ctx.Err() == nil {
+		opts.OnChat(req, resp, err, time.Since(start))
+	}
+	if err != nil {
+		return "", err
+	}
+	return resp.Message.Content, nil
+}
+
+func visionTool(ctx context.Context, c *client.ThroneClient, opts Options, raw json.RawMessage) (string, error) {
+	var args struct {
+		Model       string `json:"model"`
+		ImagePath   string `json:"image_path"`
+		ImageBase64 string `json:"image_base64"`
+		TopK        int    `json:"top_k"`
+	}
+	if err := json.Unmarshal(raw, &args); err != nil {
+		return "", fmt.Errorf("invalid arguments: %w", err)
+	}
+	if args.Model == "" {
+		return "", errors.New("model is required")
+	}
+
+	req := client.VisionRequest{Model: args.Model, Image: args.ImageBase64, TopK: args.TopK}
+	switch {
+	case args.ImagePath != "" && args.ImageBase64 != "":
+		return "", errors.New("give either image_path or image_base64, not both")
+	case args.ImagePath != "":
+		data, err := os.ReadFile(args.ImagePath)
+		if err != nil {
+			return "", fmt.Errorf("failed to read image: %w", err)
+		}
+		req.Image = base64.StdEncoding.EncodeToString(data)
+	case args.ImageBase64 == "":
+		return "", errors.New("image_path or image_base64 is required")
+	}
+	if req.TopK <= 0 {
+		req.TopK = 5
+	}
+
+	if opts.Check != nil {
+		release, err := opts.Check(client.ChatRequest{Model: req.Model})
+		if err != nil {
+			return "", err
+		}
+		defer release()
+	}
+	start := time.Now()
+	resp, err := c.ClassifyImage(ctx, req)
+	if opts.OnVision != nil && ctx.Err() == nil {
+		opts.OnVision(req, resp, err, time.Since(start))
+	}
+	if err != nil {
+		return "", err
+	}
+	return toJSON(map[string]any{"model": resp.Model, "predictions": resp.Predictions})
+}
+
+func objectSchema(properties map[string]any, required ...string) map[string]any {
+	schema := map[string]any{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+func stringProperty(description string) map[string]any {
+	return map[string]any{"type": "string", "description": description}
+}
+
+func toJSON(v any) (string, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode result: %w", err)
+	}
+	return string(data), nil
+}
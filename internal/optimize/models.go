@@ -0,0 +1,37 @@
+package optimize
+
+import (
+	"slices"
+	"strings"
+)
+
+// modelClasses groups model families that are interchangeable for most
+// tasks. Models in the same family (e.g. llama3.2:1b and llama3.2:3b) are
+// always considered equivalent.
+var modelClasses = map[string][]string{
+	"general": {"llama3", "llama3.1", "llama3.2", "llama3.3", "mistral", "mistral-nemo", "qwen2", "qwen2.5", "gemma", "gemma2", "gemma3", "phi3", "phi3.5", "phi4"},
+	"code":    {"codellama", "deepseek-coder", "deepseek-coder-v2", "qwen2.5-coder", "starcoder2", "codegemma", "codestral"},
+	"vision":  {"llava", "llama3.2-vision", "bakllava", "moondream", "minicpm-v"},
+	"embed":   {"nomic-embed-text", "mxbai-embed-large", "all-minilm", "snowflake-arctic-embed", "bge-m3"},
+}
+
+// Family returns the model name without its tag, e.g. "llama3.2" for
+// "llama3.2:3b"
+func Family(model string) string {
+	family, _, _ := strings.Cut(strings.ToLower(model), ":")
+	return family
+}
+
+// Equivalent reports whether b can stand in for a
+func Equivalent(a, b string) bool {
+	fa, fb := Family(a), Family(b)
+	if fa == fb {
+		return true
+	}
+	for _, families := range modelClasses {
+		if slices.Contains(families, fa) && slices.Contains(families, fb) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,359 @@
+// Package optimize analyzes model usage and request history for ways to
+// spend fewer credits.
+package optimize
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/sovereynai/reign/internal/client"
+	"github.com/sovereynai/reign/internal/history"
+)
+
+// Suggestion kinds
+const (
+	KindRunLocal = "run_local"
+	KindSwitch   = "switch_model"
+	KindCache    = "cache_repeats"
+	KindFailures = "failures"
+)
+
+// Suggestion is a concrete change with its estimated savings
+type Suggestion struct {
+	Kind          string  `json:"kind"`
+	Model         string  `json:"model"`
+	Title         string  `json:"title"`
+	Detail        string  `json:"detail"`
+	SavingsPerDay float64 `json:"savings_per_day"` // Credits
+	Command       string  `json:"command"`         // How to apply it
+}
+
+// Input is the data the analyzer works from. Any part may be missing.
+type Input struct {
+	Stats         *client.DeveloperStats
+	LocalModels   []string              // Models installed on this machine
+	NetworkModels []client.NetworkModel // Models available on the network
+	History       []history.Entry       // Recent requests, newest first
+	Window        time.Duration         // Period covered by History
+}
+
+// minSavings is the smallest daily saving worth suggesting
+const minSavings = 0.001
+
+// modelUsage is what is known about one model, merged from the dashboard
+// stats and request history
+type modelUsage struct {
+	name          string
+	requestsDay   float64 // Typical requests per day
+	costPerReq    float64 // Credits per request served by the network
+	latencyMs     float64
+	networkShare  float64 // Fraction of requests served by the network
+	recentEntryID string  // A recorded request, for replaying
+
+	costOverAll bool // costPerReq is still averaged over local requests too
+}
+
+// dailySpend is the credits spent on the model per day
+func (m *modelUsage) dailySpend() float64 {
+	return m.requestsDay * m.networkShare * m.costPerReq
+}
+
+// Analyze returns suggestions ordered by estimated savings, largest first
+func Analyze(in Input) []Suggestion {
+	usage := mergeUsage(in)
+
+	var out []Suggestion
+	for _, m := range usage {
+		if s, ok := runLocal(m, in); ok {
+			out = append(out, s)
+		}
+		if s, ok := switchModel(m, usage); ok {
+			out = append(out, s)
+		}
+	}
+	out = append(out, repeatedPrompts(in)...)
+	if s, ok := failedRequests(in); ok {
+		out = append(out, s)
+	}
+
+	out = slices.DeleteFunc(out, func(s Suggestion) bool { return s.SavingsPerDay < minSavings })
+	slices.SortStableFunc(out, func(a, b Suggestion) int {
+		return cmp.Compare(b.SavingsPerDay, a.SavingsPerDay)
+	})
+	return out
+}
+
+// TotalSavings sums the daily savings of suggestions. Alternatives for the
+// same model are only counted once, taking the larger.
+func TotalSavings(suggestions []Suggestion) float64 {
+	perModel := make(map[string]float64)
+	total := 0.0
+	for _, s := range suggestions {
+		if s.Kind == KindRunLocal || s.Kind == KindSwitch {
+			perModel[s.Model] = max(perModel[s.Model], s.SavingsPerDay)
+			continue
+		}
+		total += s.SavingsPerDay
+	}
+	for _, v := range perModel {
+		total += v
+	}
+	return total
+}
+
+func mergeUsage(in Input) []*modelUsage {
+	byName := make(map[string]*modelUsage)
+	get := func(name string) *modelUsage {
+		if m, ok := byName[name]; ok {
+			return m
+		}
+		m := &modelUsage{name: name, networkShare: -1}
+		byName[name] = m
+		return m
+	}
+
+	// Dashboard stats: today's spend and the weekly request rate
+	defaultShare := -1.0
+	if in.Stats != nil {
+		if in.Stats.Performance.NetworkPercent > 0 || in.Stats.Performance.LocalPercent > 0 {
+			defaultShare = in.Stats.Performance.NetworkPercent / 100
+		}
+		for _, u := range in.Stats.Models {
+			m := get(u.Name)
+			m.requestsDay = u.WeekAvg
+			if m.requestsDay == 0 {
+				m.requestsDay = float64(u.RequestsToday)
+			}
+			if u.RequestsToday > 0 && u.CreditsSpent > 0 {
+				m.costPerReq = u.CreditsSpent / float64(u.RequestsToday)
+				m.costOverAll = true
+			}
+			m.latencyMs = float64(u.AvgLatencyMs)
+		}
+	}
+
+	// History fills in what the stats lack and tells local from network
+	// requests: only the network charges credits
+	type tally struct {
+		count, paid int
+		cost        float64
+		latency     float64
+	}
+	tallies := make(map[string]*tally)
+	for _, e := range in.History {
+		if e.Kind != history.KindChat || e.Error != "" {
+			continue
+		}
+		t, ok := tallies[e.Model]
+		if !ok {
+			t = &tally{}
+			tallies[e.Model] = t
+			get(e.Model).recentEntryID = e.ID
+		}
+		t.count++
+		t.latency += float64(e.LatencyMs)
+		if e.Cost > 0 {
+			t.paid++
+			t.cost += e.Cost
+		}
+	}
+	days := max(1, in.Window.Hours()/24)
+	for name, t := range tallies {
+		m := get(name)
+		if m.requestsDay == 0 {
+			m.requestsDay = float64(t.count) / days
+		}
+		if m.costPerReq == 0 && t.paid > 0 {
+			m.costPerReq = t.cost / float64(t.paid)
+		}
+		if m.latencyMs == 0 {
+			m.latencyMs = t.latency / float64(t.count)
+		}
+		if t.count >= 5 {
+			m.networkShare = float64(t.paid) / float64(t.count)
+		}
+	}
+
+	var out []*modelUsage
+	for _, m := range byName {
+		if m.networkShare < 0 {
+			switch {
+			case defaultShare >= 0:
+				m.networkShare = defaultShare
+			default:
+				m.networkShare = 1
+			}
+		}
+		// Today's spend covers free local requests too; spread it over the
+		// network ones only
+		if m.costOverAll && m.networkShare > 0 {
+			m.costPerReq /= m.networkShare
+		}
+		out = append(out, m)
+	}
+	slices.SortFunc(out, func(a, b *modelUsage) int { return strings.Compare(a.name, b.name) })
+	return out
+}
+
+// runLocal suggests installing a model that is paid for on the network
+func runLocal(m *modelUsage, in Input) (Suggestion, bool) {
+	if slices.Contains(in.LocalModels, m.name) || m.networkShare == 0 {
+		return Suggestion{}, false
+	}
+	savings := m.dailySpend()
+
+	detail := fmt.Sprintf("%s isn't installed locally, so %.0f%% of its ~%.0f requests/day ran on the network at %.4f credits each.",
+		m.name, m.networkShare*100, m.requestsDay, m.costPerReq)
+	for _, nm := range in.NetworkModels {
+		if nm.Name == m.name && nm.PeerCount > 0 {
+			detail += fmt.Sprintf(" It can be pulled from %d peers.", nm.PeerCount)
+		}
+	}
+
+	return Suggestion{
+		Kind:          KindRunLocal,
+		Model:         m.name,
+		Title:         fmt.Sprintf("Move %.0f%% of %s traffic local", m.networkShare*100, m.name),
+		Detail:        detail,
+		SavingsPerDay: savings,
+		Command:       "ollama pull " + m.name,
+	}, true
+}
+
+// switchModel suggests the cheapest equivalent model with similar latency
+func switchModel(m *modelUsage, usage []*modelUsage) (Suggestion, bool) {
+	if m.costPerReq == 0 || m.requestsDay == 0 {
+		return Suggestion{}, false
+	}
+
+	var best *modelUsage
+	for _, alt := range usage {
+		if alt == m || alt.costPerReq == 0 || !Equivalent(m.name, alt.name) {
+			continue
+		}
+		if alt.costPerReq > m.costPerReq*0.8 {
+			continue // Not meaningfully cheaper
+		}
+		if m.latencyMs > 0 && alt.latencyMs > m.latencyMs*1.25 {
+			continue // Noticeably slower
+		}
+		if best == nil || alt.costPerReq < best.costPerReq {
+			best = alt
+		}
+	}
+	if best == nil {
+		return Suggestion{}, false
+	}
+
+	savings := (m.costPerReq - best.costPerReq) * m.requestsDay * m.networkShare
+	command := fmt.Sprintf("reign chat -m %s \"...\"", best.name)
+	if m.recentEntryID != "" {
+		command = fmt.Sprintf("reign dev history replay %s -m %s", m.recentEntryID, best.name)
+	}
+	return Suggestion{
+		Kind:  KindSwitch,
+		Model: m.name,
+		Title: fmt.Sprintf("Switch %s to %s", m.name, best.name),
+		Detail: fmt.Sprintf("%s costs %.4f credits per request vs %.4f (%.0f%% less) with similar latency (%.0fms vs %.0fms).",
+			best.name, best.costPerReq, m.costPerReq, (1-best.costPerReq/m.costPerReq)*100, best.latencyMs, m.latencyMs),
+		SavingsPerDay: savings,
+		Command:       command,
+	}, true
+}
+
+// repeatedPrompts finds identical paid requests whose responses could have
+// been reused
+func repeatedPrompts(in Input) []Suggestion {
+	type group struct {
+		model, prompt string
+		count         int
+		cost          float64 // Spent on the repeats, not the first request
+	}
+	groups := make(map[string]*group)
+	var order []string
+	// History is newest first; walk oldest first so the first request is free
+	for _, e := range slices.Backward(in.History) {
+		if e.Kind != history.KindChat || e.Error != "" || e.Content == "omitted" || e.Cost == 0 {
+			continue
+		}
+		key := e.Model + "\x00" + messagesKey(e.Messages)
+		g, ok := groups[key]
+		if !ok {
+			g = &group{model: e.Model, prompt: e.Prompt()}
+			groups[key] = g
+			order = append(order, key)
+		} else {
+			g.cost += e.Cost
+		}
+		g.count++
+	}
+
+	days := max(1, in.Window.Hours()/24)
+	repeats, cost := 0, 0.0
+	var top *group
+	for _, key := range order {
+		g := groups[key]
+		if g.count < 2 {
+			continue
+		}
+		repeats += g.count - 1
+		cost += g.cost
+		if top == nil || g.cost > top.cost {
+			top = g
+		}
+	}
+	if repeats < 3 {
+		return nil
+	}
+
+	preview := strings.Join(strings.Fields(top.prompt), " ")
+	if r := []rune(preview); len(r) > 40 {
+		preview = string(r[:40])
+	}
+	return []Suggestion{{
+		Kind:  KindCache,
+		Model: top.model,
+		Title: fmt.Sprintf("Reuse answers to %d repeated prompts", repeats),
+		Detail: fmt.Sprintf("The same prompt was sent to the same model %d extra times in the last %.0f days, costing %.4f credits. The most repeated went to %s.",
+			repeats, days, cost, top.model),
+		SavingsPerDay: cost / days,
+		Command:       fmt.Sprintf("reign dev history search %q", preview),
+	}}
+}
+
+// failedRequests reports credits charged for requests that failed
+func failedRequests(in Input) (Suggestion, bool) {
+	count, cost := 0, 0.0
+	for _, e := range in.History {
+		if e.Error != "" && e.Cost > 0 {
+			count++
+			cost += e.Cost
+		}
+	}
+	if count == 0 {
+		return Suggestion{}, false
+	}
+
+	days := max(1, in.Window.Hours()/24)
+	return Suggestion{
+		Kind:          KindFailures,
+		Title:         fmt.Sprintf("Fix %d failing requests", count),
+		Detail:        fmt.Sprintf("%d requests in the last %.0f days failed but were still charged %.4f credits.", count, days, cost),
+		SavingsPerDay: cost / days,
+		Command:       fmt.Sprintf("reign dev history list --since %.0fd", days),
+	}, true
+}
+
+func messagesKey(messages []client.ChatMessage) string {
+	var b strings.Builder
+	for _, m := range messages {
+		b.WriteString(m.Role)
+		b.WriteByte(0)
+		b.WriteString(m.Content)
+		b.WriteByte(0)
+	}
+	return b.String()
+}
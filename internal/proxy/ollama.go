@@ -0,0 +1,316 @@
+package proxy
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/sovereynai/reign/internal/client"
+	"github.com/sovereynai/reign/internal/estimate"
+)
+
+// ollamaVersion is the Ollama API version reported to clients
+const ollamaVersion = "0.5.0"
+
+// Ollama serves the chat, generate, embed and tags endpoints of the Ollama
+// API. Tags list the network's models along with local ones, so tools built
+// for a local Ollama can use them too.
+type Ollama struct {
+	client *client.ThroneClient
+	opts   Options
+	mux    *http.ServeMux
+}
+
+// NewOllama creates an Ollama API server backed by throne
+func NewOllama(c *client.ThroneClient, opts Options) *Ollama {
+	s := &Ollama{client: c, opts: opts, mux: http.NewServeMux()}
+	s.mux.HandleFunc("POST /api/chat", s.chat)
+	s.mux.HandleFunc("POST /api/generate", s.generate)
+	s.mux.HandleFunc("POST /api/embed", s.embed)
+	s.mux.HandleFunc("GET /api/tags", s.tags)
+	s.mux.HandleFunc("GET /api/version", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{"version": ollamaVersion})
+	})
+	s.mux.HandleFunc("GET /{$}", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "Ollama is running")
+	})
+	s.mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeOllamaError(w, http.StatusNotFound, fmt.Sprintf("%s %s is not supported", r.Method, r.URL.Path))
+	})
+	return s
+}
+
+// ServeHTTP handles Ollama API requests
+func (s *Ollama) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// ollamaOptions are the generation options passed through to throne
+type ollamaOptions struct {
+	Temperature *float64 `json:"temperature"`
+	NumPredict  int      `json:"num_predict"`
+}
+
+func (o *ollamaOptions) chatOptions() *client.ChatOptions {
+	if o == nil || (o.Temperature == nil && o.NumPredict <= 0) {
+		return nil
+	}
+	return &client.ChatOptions{Temperature: o.Temperature, MaxTokens: max(0, o.NumPredict)}
+}
+
+type ollamaMessage struct {
+	Role    string   `json:"role"`
+	Content string   `json:"content"`
+	Images  []string `json:"images,omitempty"`
+}
+
+type ollamaChatRequest struct {
+	Model    string          `json:"model"`
+	Messages []ollamaMessage `json:"messages"`
+	Stream   *bool           `json:"stream"` // Defaults to true
+	Options  *ollamaOptions  `json:"options"`
+}
+
+type ollamaGenerateRequest struct {
+	Model   string         `json:"model"`
+	Prompt  string         `json:"prompt"`
+	System  string         `json:"system"`
+	Images  []string       `json:"images"`
+	Stream  *bool          `json:"stream"` // Defaults to true
+	Options *ollamaOptions `json:"options"`
+}
+
+// ollamaResponse is a chat or generate response, or a piece of a streamed one
+type ollamaResponse struct {
+	Model           string         `json:"model"`
+	CreatedAt       time.Time      `json:"created_at"`
+	Message         *ollamaMessage `json:"message,omitempty"`  // Chat
+	Response        *string        `json:"response,omitempty"` // Generate
+	Done            bool           `json:"done"`
+	DoneReason      string         `json:"done_reason,omitempty"`
+	TotalDuration   int64          `json:"total_duration,omitempty"` // Nanoseconds
+	PromptEvalCount int            `json:"prompt_eval_count,omitempty"`
+	EvalCount       int            `json:"eval_count,omitempty"`
+}
+
+func (s *Ollama) chat(w http.ResponseWriter, r *http.Request) {
+	var body ollamaChatRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeOllamaError(w, http.StatusBadRequest, "invalid JSON body: "+err.Error())
+		return
+	}
+	if body.Model == "" {
+		writeOllamaError(w, http.StatusBadRequest, "model is required")
+		return
+	}
+	req := client.ChatRequest{Model: body.Model, Options: body.Options.chatOptions()}
+	for _, m := range body.Messages {
+		if len(m.Images) > 0 {
+			writeOllamaError(w, http.StatusBadRequest, "images are not supported")
+			return
+		}
+		req.Messages = append(req.Messages, client.ChatMessage{Role: m.Role, Content: m.Content})
+	}
+	if len(req.Messages) == 0 {
+		// Ollama loads the model when there are no messages
+		writeJSON(w, http.StatusOK, ollamaResponse{
+			Model:      body.Model,
+			CreatedAt:  time.Now().UTC(),
+			Message:    &ollamaMessage{Role: "assistant"},
+			Done:       true,
+			DoneReason: "load",
+		})
+		return
+	}
+
+	s.respond(w, r, req, body.Stream == nil || *body.Stream, func(resp *ollamaResponse, content string) {
+		resp.Message = &ollamaMessage{Role: "assistant", Content: content}
+	})
+}
+
+func (s *Ollama) generate(w http.ResponseWriter, r *http.Request) {
+	var body ollamaGenerateRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeOllamaError(w, http.StatusBadRequest, "invalid JSON body: "+err.Error())
+		return
+	}
+	switch {
+	case body.Model == "":
+		writeOllamaError(w, http.StatusBadRequest, "model is required")
+		return
+	case len(body.Images) > 0:
+		writeOllamaError(w, http.StatusBadRequest, "images are not supported")
+		return
+	case body.Prompt == "":
+		// Ollama loads the model when there is no prompt
+		empty := ""
+		writeJSON(w, http.StatusOK, ollamaResponse{Model: body.Model, CreatedAt: time.Now().UTC(), Response: &empty, Done: true, DoneReason: "load"})
+		return
+	}
+
+	req := client.ChatRequest{Model: body.Model, Options: body.Options.chatOptions()}
+	if body.System != "" {
+		req.Messages = append(req.Messages, client.ChatMessage{Role: "system", Content: body.System})
+	}
+	req.Messages = append(req.Messages, client.ChatMessage{Role: "user", Content: body.Prompt})
+
+	s.respond(w, r, req, body.Stream == nil || *body.Stream, func(resp *ollamaResponse, content string) {
+		resp.Response = &content
+	})
+}
+
+// respond sends a chat request and answers with its output, streamed as
+// newline-delimited JSON or all at once. setContent puts output in a
+// response the way the endpoint does.
+func (s *Ollama) respond(w http.ResponseWriter, r *http.Request, req client.ChatRequest, stream bool, setContent func(resp *ollamaResponse, content string)) {
+	if s.opts.Check != nil {
+		release, err := s.opts.Check(req)
+		if err != nil {
+			writeOllamaError(w, http.StatusTooManyRequests, err.Error())
+			return
+		}
+		defer release()
+	}
+
+	start := time.Now()
+	final := func(content string, resp *client.ChatResponse) ollamaResponse {
+		out := ollamaResponse{
+			Model:           req.Model,
+			CreatedAt:       time.Now().UTC(),
+			Done:            true,
+			DoneReason:      "stop",
+			TotalDuration:   time.Since(start).Nanoseconds(),
+			PromptEvalCount: estimate.PromptTokens(req.Messages),
+			EvalCount:       estimate.Tokens(resp.Message.Content),
+		}
+		setContent(&out, content)
+		return out
+	}
+
+	if !stream {
+		resp, err := sendChat(r, s.client, s.opts, req, nil)
+		if err != nil {
+			writeOllamaError(w, throneStatus(w, err), err.Error())
+			return
+		}
+		writeJSON(w, http.StatusOK, final(resp.Message.Content, resp))
+		return
+	}
+
+	// Headers are sent with the first piece of output, so failures before it
+	// get a normal error response
+	rc := http.NewResponseController(w)
+	enc := json.NewEncoder(w)
+	started := false
+	resp, err := sendChat(r, s.client, s.opts, req, func(delta string) {
+		if !started {
+			started = true
+			w.Header().Set("Content-Type", "application/x-ndjson")
+			w.WriteHeader(http.StatusOK)
+		}
+		chunk := ollamaResponse{Model: req.Model, CreatedAt: time.Now().UTC()}
+		setContent(&chunk, delta)
+		enc.Encode(chunk)
+		rc.Flush()
+	})
+	if err != nil {
+		if !started {
+			writeOllamaError(w, throneStatus(w, err), err.Error())
+			return
+		}
+		enc.Encode(ollamaError{Error: err.Error()})
+		rc.Flush()
+		return
+	}
+	if !started {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+	}
+	enc.Encode(final("", resp))
+	rc.Flush()
+}
+
+func (s *Ollama) embed(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Model string          `json:"model"`
+		Input json.RawMessage `json:"input"` // A string or an array of strings
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeOllamaError(w, http.StatusBadRequest, "invalid JSON body: "+err.Error())
+		return
+	}
+	req := client.EmbedRequest{Model: body.Model}
+	var one string
+	if err := json.Unmarshal(body.Input, &one); err == nil {
+		req.Input = []string{one}
+	} else if err := json.Unmarshal(body.Input, &req.Input); err != nil {
+		writeOllamaError(w, http.StatusBadRequest, "input must be a string or an array of strings")
+		return
+	}
+	if req.Model == "" || len(req.Input) == 0 {
+		writeOllamaError(w, http.StatusBadRequest, "model and input are required")
+		return
+	}
+	if s.opts.Check != nil {
+		release, err := s.opts.Check(client.ChatRequest{Model: req.Model})
+		if err != nil {
+			writeOllamaError(w, http.StatusTooManyRequests, err.Error())
+			return
+		}
+		defer release()
+	}
+
+	start := time.Now()
+	resp, err := sendEmbed(r, s.client, s.opts, req)
+	if err != nil {
+		writeOllamaError(w, throneStatus(w, err), err.Error())
+		return
+	}
+	tokens := 0
+	for _, in := range req.Input {
+		tokens += estimate.Tokens(in)
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"model":             req.Model,
+		"embeddings":        resp.Embeddings,
+		"total_duration":    time.Since(start).Nanoseconds(),
+		"prompt_eval_count": tokens,
+	})
+}
+
+type ollamaModel struct {
+	Name       string       `json:"name"`
+	Model      string       `json:"model"`
+	ModifiedAt time.Time    `json:"modified_at"`
+	Size       int64        `json:"size"`
+	Digest     string       `json:"digest"`
+	Details    ollamaDetail `json:"details"`
+}
+
+type ollamaDetail struct {
+	Format            string `json:"format"`
+	Family            string `json:"family"`
+	ParameterSize     string `json:"parameter_size"`
+	QuantizationLevel string `json:"quantization_level"`
+}
+
+func (s *Ollama) tags(w http.ResponseWriter, r *http.Request) {
+	models, err := listModels(s.client)
+	if err != nil {
+		writeOllamaError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+	tags := []ollamaModel{}
+	for _, m := range models {
+		tags = append(tags, ollamaModel{Name: m.Name, Model: m.Name})
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"models": tags})
+}
+
+type ollamaError struct {
+	Error string `json:"error"`
+}
+
+func writeOllamaError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, ollamaError{Error: message})
+}
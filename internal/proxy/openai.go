@@ -0,0 +1,398 @@
+package proxy
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sovereynai/reign/internal/client"
+	"github.com/sovereynai/reign/internal/estimate"
+)
+
+// OpenAI serves the chat completions, models and embeddings endpoints of the
+// OpenAI API under /v1. Token counts in usage are approximate, as throne
+// doesn't report them.
+type OpenAI struct {
+	client *client.ThroneClient
+	opts   Options
+	mux    *http.ServeMux
+}
+
+// NewOpenAI creates an OpenAI API server backed by throne
+func NewOpenAI(c *client.ThroneClient, opts Options) *OpenAI {
+	s := &OpenAI{client: c, opts: opts, mux: http.NewServeMux()}
+	s.mux.HandleFunc("POST /v1/chat/completions", s.chatCompletions)
+	s.mux.HandleFunc("GET /v1/models", s.listModels)
+	s.mux.HandleFunc("GET /v1/models/{model...}", s.getModel)
+	s.mux.HandleFunc("POST /v1/embeddings", s.embeddings)
+	s.mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeOpenAIError(w, http.StatusNotFound, "invalid_request_error", fmt.Sprintf("%s %s is not supported", r.Method, r.URL.Path))
+	})
+	return s
+}
+
+// ServeHTTP handles OpenAI API requests
+func (s *OpenAI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+type openAIMessage struct {
+	Role    string          `json:"role"`
+	Content json.RawMessage `json:"content"` // A string, or an array of content parts
+}
+
+type chatCompletionRequest struct {
+	Model         string          `json:"model"`
+	Messages      []openAIMessage `json:"messages"`
+	Stream        bool            `json:"stream"`
+	StreamOptions *struct {
+		IncludeUsage bool `json:"include_usage"`
+	} `json:"stream_options"`
+	Temperature         *float64 `json:"temperature"`
+	MaxTokens           int      `json:"max_tokens"`
+	MaxCompletionTokens int      `json:"max_completion_tokens"`
+	N                   int      `json:"n"`
+}
+
+type chatCompletionChoice struct {
+	Index        int        `json:"index"`
+	Message      *chatDelta `json:"message,omitempty"`
+	Delta        *chatDelta `json:"delta,omitempty"`
+	FinishReason *string    `json:"finish_reason"`
+}
+
+type chatDelta struct {
+	Role    string `json:"role,omitempty"`
+	Content string `json:"content"`
+}
+
+type openAIUsage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
+type chatCompletion struct {
+	ID      string                 `json:"id"`
+	Object  string                 `json:"object"`
+	Created int64                  `json:"created"`
+	Model   string                 `json:"model"`
+	Choices []chatCompletionChoice `json:"choices"`
+	Usage   *openAIUsage           `json:"usage,omitempty"`
+}
+
+func (s *OpenAI) chatCompletions(w http.ResponseWriter, r *http.Request) {
+	var body chatCompletionRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeOpenAIError(w, http.StatusBadRequest, "invalid_request_error", "invalid JSON body: "+err.Error())
+		return
+	}
+	req, err := body.chatRequest()
+	if err != nil {
+		writeOpenAIError(w, http.StatusBadRequest, "invalid_request_error", err.Error())
+		return
+	}
+	if s.opts.Check != nil {
+		release, err := s.opts.Check(req)
+		if err != nil {
+			writeOpenAIError(w, http.StatusTooManyRequests, "insufficient_quota", err.Error())
+			return
+		}
+		defer release()
+	}
+
+	completion := chatCompletion{
+		ID:      newID("chatcmpl-"),
+		Object:  "chat.completion",
+		Created: time.Now().Unix(),
+		Model:   req.Model,
+	}
+	stop := "stop"
+
+	if !body.Stream {
+		resp, err := sendChat(r, s.client, s.opts, req, nil)
+		if err != nil {
+			writeThroneError(w, err)
+			return
+		}
+		completion.Choices = []chatCompletionChoice{{
+			Message:      &chatDelta{Role: "assistant", Content: resp.Message.Content},
+			FinishReason: &stop,
+		}}
+		completion.Usage = usage(req, resp.Message.Content)
+		writeJSON(w, http.StatusOK, completion)
+		return
+	}
+
+	// Streamed as server-sent events. Headers are sent with the first piece
+	// of output, so failures before it get a normal error response.
+	completion.Object = "chat.completion.chunk"
+	rc := http.NewResponseController(w)
+	started := false
+	send := func(choices []chatCompletionChoice, u *openAIUsage) {
+		if !started {
+			started = true
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.Header().Set("Cache-Control", "no-cache")
+			w.WriteHeader(http.StatusOK)
+			first := completion
+			first.Choices = []chatCompletionChoice{{Delta: &chatDelta{Role: "assistant"}}}
+			writeEvent(w, first)
+		}
+		chunk := completion
+		chunk.Choices = choices
+		chunk.Usage = u
+		writeEvent(w, chunk)
+		rc.Flush()
+	}
+
+	resp, err := sendChat(r, s.client, s.opts, req, func(delta string) {
+		send([]chatCompletionChoice{{Delta: &chatDelta{Content: delta}}}, nil)
+	})
+	if err != nil {
+		if !started {
+			writeThroneError(w, err)
+			return
+		}
+		writeEvent(w, openAIError{Error: openAIErrorBody{Message: err.Error(), Type: "api_error"}})
+		rc.Flush()
+		return
+	}
+	send([]chatCompletionChoice{{Delta: &chatDelta{}, FinishReason: &stop}}, nil)
+	if body.StreamOptions != nil && body.StreamOptions.IncludeUsage {
+		send([]chatCompletionChoice{}, usage(req, resp.Message.Content))
+	}
+	fmt.Fprint(w, "data: [DONE]\n\n")
+	rc.Flush()
+}
+
+// chatRequest translates the request to throne's
+func (b chatCompletionRequest) chatRequest() (client.ChatRequest, error) {
+	req := client.ChatRequest{Model: b.Model}
+	if b.Model == "" {
+		return req, errors.New("model is required")
+	}
+	if len(b.Messages) == 0 {
+		return req, errors.New("messages must not be empty")
+	}
+	if b.N > 1 {
+		return req, errors.New("only n=1 is supported")
+	}
+
+	for i, m := range b.Messages {
+		content, err := messageText(m.Content)
+		if err != nil {
+			return req, fmt.Errorf("messages[%d]: %w", i, err)
+		}
+		role := m.Role
+		if role == "developer" {
+			role = "system"
+		}
+		req.Messages = append(req.Messages, client.ChatMessage{Role: role, Content: content})
+	}
+
+	maxTokens := b.MaxCompletionTokens
+	if maxTokens == 0 {
+		maxTokens = b.MaxTokens
+	}
+	if b.Temperature != nil || maxTokens > 0 {
+		req.Options = &client.ChatOptions{Temperature: b.Temperature, MaxTokens: maxTokens}
+	}
+	return req, nil
+}
+
+// messageText reads a message's content, given either as a string or as
+// text parts
+func messageText(raw json.RawMessage) (string, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return "", nil
+	}
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		return text, nil
+	}
+
+	var parts []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	}
+	if err := json.Unmarshal(raw, &parts); err != nil {
+		return "", errors.New("content must be a string or an array of content parts")
+	}
+	var b strings.Builder
+	for _, p := range parts {
+		if p.Type != "text" {
+			return "", fmt.Errorf("content parts of type %q are not supported", p.Type)
+		}
+		b.WriteString(p.Text)
+	}
+	return b.String(), nil
+}
+
+func usage(req client.ChatRequest, response string) *openAIUsage {
+	u := &openAIUsage{
+		PromptTokens:     estimate.PromptTokens(req.Messages),
+		CompletionTokens: estimate.Tokens(response),
+	}
+	u.TotalTokens = u.PromptTokens + u.CompletionTokens
+	return u
+}
+
+type openAIModel struct {
+	ID      string `json:"id"`
+	Object  string `json:"object"`
+	Created int64  `json:"created"`
+	OwnedBy string `json:"owned_by"` // "local" or "sovereyn" for network models
+}
+
+func toOpenAIModel(m model) openAIModel {
+	owner := "sovereyn"
+	if m.Local {
+		owner = "local"
+	}
+	return openAIModel{ID: m.Name, Object: "model", OwnedBy: owner}
+}
+
+func (s *OpenAI) listModels(w http.ResponseWriter, r *http.Request) {
+	models, err := listModels(s.client)
+	if err != nil {
+		writeOpenAIError(w, http.StatusBadGateway, "api_error", err.Error())
+		return
+	}
+	data := []openAIModel{}
+	for _, m := range models {
+		data = append(data, toOpenAIModel(m))
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"object": "list", "data": data})
+}
+
+func (s *OpenAI) getModel(w http.ResponseWriter, r *http.Request) {
+	models, err := listModels(s.client)
+	if err != nil {
+		writeOpenAIError(w, http.StatusBadGateway, "api_error", err.Error())
+		return
+	}
+	name := r.PathValue("model")
+	for _, m := range models {
+		if m.Name == name {
+			writeJSON(w, http.StatusOK, toOpenAIModel(m))
+			return
+		}
+	}
+	writeOpenAIError(w, http.StatusNotFound, "invalid_request_error", fmt.Sprintf("model %q not found", name))
+}
+
+type embeddingsRequest struct {
+	Model          string          `json:"model"`
+	Input          json.RawMessage `json:"input"` // A string or an array of strings
+	EncodingFormat string          `json:"encoding_format"`
+}
+
+type embedding struct {
+	Object    string `json:"object"`
+	Index     int    `json:"index"`
+	Embedding any    `json:"embedding"` // Floats, or base64 of little-endian float32s
+}
+
+func (s *OpenAI) embeddings(w http.ResponseWriter, r *http.Request) {
+	var body embeddingsRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeOpenAIError(w, http.StatusBadRequest, "invalid_request_error", "invalid JSON body: "+err.Error())
+		return
+	}
+	req := client.EmbedRequest{Model: body.Model}
+	var one string
+	if err := json.Unmarshal(body.Input, &one); err == nil {
+		req.Input = []string{one}
+	} else if err := json.Unmarshal(body.Input, &req.Input); err != nil {
+		writeOpenAIError(w, http.StatusBadRequest, "invalid_request_error", "input must be a string or an array of strings")
+		return
+	}
+	switch {
+	case req.Model == "":
+		writeOpenAIError(w, http.StatusBadRequest, "invalid_request_error", "model is required")
+		return
+	case len(req.Input) == 0:
+		writeOpenAIError(w, http.StatusBadRequest, "invalid_request_error", "input must not be empty")
+		return
+	case body.EncodingFormat != "" && body.EncodingFormat != "float" && body.EncodingFormat != "base64":
+		writeOpenAIError(w, http.StatusBadRequest, "invalid_request_error", "encoding_format must be float or base64")
+		return
+	}
+	if s.opts.Check != nil {
+		release, err := s.opts.Check(client.ChatRequest{Model: req.Model})
+		if err != nil {
+			writeOpenAIError(w, http.StatusTooManyRequests, "insufficient_quota", err.Error())
+			return
+		}
+		defer release()
+	}
+
+	resp, err := sendEmbed(r, s.client, s.opts, req)
+	if err != nil {
+		writeThroneError(w, err)
+		return
+	}
+
+	data := make([]embedding, len(resp.Embeddings))
+	tokens := 0
+	for i, e := range resp.Embeddings {
+		data[i] = embedding{Object: "embedding", Index: i, Embedding: e}
+		if body.EncodingFormat == "base64" {
+			data[i].Embedding = encodeFloat32s(e)
+		}
+		tokens += estimate.Tokens(req.Input[i])
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"object": "list",
+		"data":   data,
+		"model":  req.Model,
+		"usage":  map[string]int{"prompt_tokens": tokens, "total_tokens": tokens},
+	})
+}
+
+// encodeFloat32s encodes an embedding the way OpenAI's base64 format does
+func encodeFloat32s(values []float64) string {
+	b := make([]byte, 4*len(values))
+	for i, v := range values {
+		binary.LittleEndian.PutUint32(b[4*i:], math.Float32bits(float32(v)))
+	}
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+type openAIErrorBody struct {
+	Message string  `json:"message"`
+	Type    string  `json:"type"`
+	Param   *string `json:"param"`
+	Code    *string `json:"code"`
+}
+
+type openAIError struct {
+	Error openAIErrorBody `json:"error"`
+}
+
+// writeThroneError answers with a failed throne request's error
+func writeThroneError(w http.ResponseWriter, err error) {
+	status := throneStatus(w, err)
+	errType := "api_error"
+	if status == http.StatusTooManyRequests {
+		errType = "rate_limit_error"
+	}
+	writeOpenAIError(w, status, errType, err.Error())
+}
+
+func writeOpenAIError(w http.ResponseWriter, status int, errType, message string) {
+	writeJSON(w, status, openAIError{Error: openAIErrorBody{Message: message, Type: errType}})
+}
+
+// writeEvent writes a server-sent event with a JSON payload
+func writeEvent(w http.ResponseWriter, v any) {
+	data, _ := json.Marshal(v)
+	fmt.Fprintf(w, "data: %s\n\n", data)
+}
@@ -0,0 +1,118 @@
+// Package proxy serves the APIs of other model servers on top of throne, so
+// tools built for them can use the Sovereyn network unchanged.
+package proxy
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/sovereynai/reign/internal/client"
+)
+
+// Options are hooks around the requests a proxy sends to throne
+type Options struct {
+	// Check can refuse a request before it is sent, e.g. when it would go
+	// over budget. Embedding requests are checked with just their model.
+	// When the request is allowed, release is called once it is finished
+	// and recorded, so checks can account for the requests in flight.
+	Check func(req client.ChatRequest) (release func(), err error)
+
+	// OnChat is called after each chat request, whether or not it succeeded
+	OnChat func(req client.ChatRequest, resp *client.ChatResponse, err error, elapsed time.Duration)
+
+	// OnEmbed is called after each embedding request
+	OnEmbed func(req client.EmbedRequest, resp *client.EmbedResponse, err error, elapsed time.Duration)
+}
+
+// model is a model that can be served, installed locally or on the network
+type model struct {
+	Name  string
+	Local bool
+}
+
+// listModels lists local models followed by the network's other models.
+// Either list failing is tolerated, but not both.
+func listModels(c *client.ThroneClient) ([]model, error) {
+	local, localErr := c.ListModels()
+	network, networkErr := c.ListNetworkModels()
+	if localErr != nil && networkErr != nil {
+		return nil, localErr
+	}
+
+	var models []model
+	for _, name := range local {
+		models = append(models, model{Name: name, Local: true})
+	}
+	for _, m := range network {
+		if !slices.Contains(local, m.Name) {
+			models = append(models, model{Name: m.Name})
+		}
+	}
+	return models, nil
+}
+
+// sendChat sends a chat request through the hooks. Responses that report a
+// failure are returned as errors.
+func sendChat(r *http.Request, c *client.ThroneClient, opts Options, req client.ChatRequest, onDelta func(string)) (*client.ChatResponse, error) {
+	start := time.Now()
+	var resp *client.ChatResponse
+	var err error
+	if onDelta != nil {
+		resp, err = c.StreamChat(r.Context(), req, onDelta)
+	} else {
+		resp, err = c.SendChatContext(r.Context(), req)
+		if err == nil && !resp.Success {
+			err = errors.New("inference failed")
+			if resp.Error != "" {
+				err = errors.New(resp.Error)
+			}
+		}
+	}
+	if opts.OnChat != nil && r.Context().Err() == nil {
+		opts.OnChat(req, resp, err, time.Since(start))
+	}
+	return resp, err
+}
+
+// sendEmbed sends an embedding request through the hooks
+func sendEmbed(r *http.Request, c *client.ThroneClient, opts Options, req client.EmbedRequest) (*client.EmbedResponse, error) {
+	start := time.Now()
+	resp, err := c.Embed(r.Context(), req)
+	if opts.OnEmbed != nil && r.Context().Err() == nil {
+		opts.OnEmbed(req, resp, err, time.Since(start))
+	}
+	return resp, err
+}
+
+// throneStatus is the status to answer with when a throne request fails.
+// Rate limits are passed on with throne's Retry-After.
+func throneStatus(w http.ResponseWriter, err error) int {
+	var limited *client.RateLimitError
+	if errors.As(err, &limited) {
+		if limited.RetryAfter > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(limited.RetryAfter.Seconds()))))
+		}
+		return http.StatusTooManyRequests
+	}
+	return http.StatusBadGateway
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// newID returns a random ID with a prefix, like OpenAI's "chatcmpl-..."
+func newID(prefix string) string {
+	b := make([]byte, 12)
+	rand.Read(b)
+	return prefix + hex.EncodeToString(b)
+}
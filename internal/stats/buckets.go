@@ -0,0 +1,76 @@
+package stats
+
+import "time"
+
+// Aggregation reduces the values that fall into one bucket to a single value
+type Aggregation func(values []float64) float64
+
+// Max aggregates to the largest value
+func Max(values []float64) float64 {
+	m := values[0]
+	for _, v := range values[1:] {
+		m = max(m, v)
+	}
+	return m
+}
+
+// Sum aggregates to the total of all values
+func Sum(values []float64) float64 {
+	total := 0.0
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
+
+// Mean aggregates to the average value
+func Mean(values []float64) float64 {
+	return Sum(values) / float64(len(values))
+}
+
+// Deltas converts a series of running totals into per-sample increases.
+// Decreases (counter resets, rolling windows) count as zero.
+func Deltas(points []Point) []Point {
+	var out []Point
+	for i := 1; i < len(points); i++ {
+		out = append(out, Point{
+			Time:  points[i].Time,
+			Value: max(0, points[i].Value-points[i-1].Value),
+		})
+	}
+	return out
+}
+
+// Buckets groups points into n consecutive buckets of the given size starting
+// at from. Each result point carries the bucket start time; empty buckets
+// have a value of zero.
+func Buckets(points []Point, from time.Time, size time.Duration, n int, agg Aggregation) []Point {
+	grouped := make([][]float64, n)
+	for _, p := range points {
+		if p.Time.Before(from) {
+			continue
+		}
+		i := int(p.Time.Sub(from) / size)
+		if i < n {
+			grouped[i] = append(grouped[i], p.Value)
+		}
+	}
+
+	out := make([]Point, n)
+	for i := range out {
+		out[i].Time = from.Add(time.Duration(i) * size)
+		if len(grouped[i]) > 0 {
+			out[i].Value = agg(grouped[i])
+		}
+	}
+	return out
+}
+
+// Values returns just the values of the points
+func Values(points []Point) []float64 {
+	values := make([]float64, len(points))
+	for i, p := range points {
+		values[i] = p.Value
+	}
+	return values
+}
@@ -0,0 +1,140 @@
+package stats
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sovereynai/reign/internal/client"
+)
+
+// Metric extracts a single numeric value from dashboard stats
+type Metric struct {
+	Name        string
+	Description string
+	Unit        string
+	value       func(s *client.DashboardStats) (float64, bool)
+}
+
+// Value returns the metric value for the stats, or false if the stats do not
+// contain it (e.g. operator metrics on a developer-only node)
+func (m Metric) Value(s *client.DashboardStats) (float64, bool) {
+	if s == nil {
+		return 0, false
+	}
+	return m.value(s)
+}
+
+// Point is a metric value at a point in time
+type Point struct {
+	Time  time.Time `json:"time"`
+	Value float64   `json:"value"`
+}
+
+func developer(f func(d *client.DeveloperStats) float64) func(*client.DashboardStats) (float64, bool) {
+	return func(s *client.DashboardStats) (float64, bool) {
+		if s.Developer == nil {
+			return 0, false
+		}
+		return f(s.Developer), true
+	}
+}
+
+func operator(f func(o *client.OperatorStats) float64) func(*client.DashboardStats) (float64, bool) {
+	return func(s *client.DashboardStats) (float64, bool) {
+		if s.Operator == nil {
+			return 0, false
+		}
+		return f(s.Operator), true
+	}
+}
+
+func network(f func(n *client.NetworkStats) float64) func(*client.DashboardStats) (float64, bool) {
+	return func(s *client.DashboardStats) (float64, bool) {
+		return f(&s.Network), true
+	}
+}
+
+var metrics = []Metric{
+	// Developer
+	{"balance", "Credit balance", "credits", developer(func(d *client.DeveloperStats) float64 { return d.Credits.Balance })},
+	{"burn_rate", "Credits spent per day", "credits/day", developer(func(d *client.DeveloperStats) float64 { return d.Credits.BurnRate })},
+	{"runway_days", "Days of credit left at current usage", "days", developer(func(d *client.DeveloperStats) float64 { return float64(d.Credits.RunwayDays) })},
+	{"today_spent", "Credits spent today", "credits", developer(func(d *client.DeveloperStats) float64 { return d.Credits.TodaySpent })},
+	{"requests_today", "Inference requests made today", "requests", developer(func(d *client.DeveloperStats) float64 { return float64(d.Inference.Today) })},
+	{"requests_total", "Inference requests made in total", "requests", developer(func(d *client.DeveloperStats) float64 { return float64(d.Inference.Total) })},
+	{"success_rate", "Inference success rate", "%", developer(func(d *client.DeveloperStats) float64 { return d.Inference.SuccessRate })},
+	{"avg_latency_ms", "Average inference latency", "ms", developer(func(d *client.DeveloperStats) float64 { return float64(d.Performance.AvgLatencyMs) })},
+	{"p50_latency_ms", "Median inference latency", "ms", developer(func(d *client.DeveloperStats) float64 { return float64(d.Performance.P50LatencyMs) })},
+	{"p95_latency_ms", "95th percentile inference latency", "ms", developer(func(d *client.DeveloperStats) float64 { return float64(d.Performance.P95LatencyMs) })},
+	{"p99_latency_ms", "99th percentile inference latency", "ms", developer(func(d *client.DeveloperStats) float64 { return float64(d.Performance.P99LatencyMs) })},
+	{"local_percent", "Share of requests served locally", "%", developer(func(d *client.DeveloperStats) float64 { return d.Performance.LocalPercent })},
+
+	// Operator
+	{"earnings_today", "Credits earned today", "credits", operator(func(o *client.OperatorStats) float64 { return o.Earnings.Today })},
+	{"earnings_week", "Credits earned this week", "credits", operator(func(o *client.OperatorStats) float64 { return o.Earnings.ThisWeek })},
+	{"earnings_all_time", "Credits earned in total", "credits", operator(func(o *client.OperatorStats) float64 { return o.Earnings.AllTime })},
+	{"earnings_pending", "Credits pending settlement", "credits", operator(func(o *client.OperatorStats) float64 { return o.Earnings.Pending })},
+	{"rank", "Node rank by earnings", "", operator(func(o *client.OperatorStats) float64 { return float64(o.Earnings.Rank) })},
+	{"requests_served", "Requests served in the last 24h", "requests", operator(func(o *client.OperatorStats) float64 { return float64(o.Workload.RequestsServed) })},
+	{"serve_latency_ms", "Average serving latency", "ms", operator(func(o *client.OperatorStats) float64 { return float64(o.Workload.AvgLatencyMs) })},
+	{"gpu_percent", "GPU utilization", "%", operator(func(o *client.OperatorStats) float64 { return o.Hardware.GPU.Percent })},
+	{"cpu_percent", "CPU utilization", "%", operator(func(o *client.OperatorStats) float64 { return o.Hardware.CPU.Percent })},
+	{"ram_percent", "RAM utilization", "%", operator(func(o *client.OperatorStats) float64 { return o.Hardware.RAM.Percent })},
+	{"gpu_temp", "GPU temperature", "C", operator(func(o *client.OperatorStats) float64 { return o.Hardware.Temperature.GPU })},
+	{"power_watts", "Average power draw", "W", operator(func(o *client.OperatorStats) float64 { return o.Hardware.PowerWatts })},
+	{"reputation", "Reputation score", "", operator(func(o *client.OperatorStats) float64 { return o.Reputation.Score })},
+
+	// Network
+	{"peers", "Connected peers", "peers", network(func(n *client.NetworkStats) float64 { return float64(n.PeersConnected) })},
+	{"models_available", "Models available on the network", "models", network(func(n *client.NetworkStats) float64 { return float64(n.ModelsAvailable) })},
+	{"queue_depth", "Network queue depth", "jobs", network(func(n *client.NetworkStats) float64 { return float64(n.QueueDepth) })},
+	{"est_wait_sec", "Estimated queue wait", "s", network(func(n *client.NetworkStats) float64 { return n.EstWaitSec })},
+}
+
+// Metrics lists all metrics that can be queried from history
+func Metrics() []Metric {
+	return metrics
+}
+
+// LookupMetric finds a metric by name
+func LookupMetric(name string) (Metric, error) {
+	for _, m := range metrics {
+		if m.Name == name {
+			return m, nil
+		}
+	}
+	return Metric{}, fmt.Errorf("unknown metric %q - run 'reign stats metrics' to list them", name)
+}
+
+// Series extracts a metric from snapshots, skipping snapshots without it
+func Series(snaps []Snapshot, m Metric) []Point {
+	var points []Point
+	for _, snap := range snaps {
+		if v, ok := m.Value(snap.Stats); ok {
+			points = append(points, Point{Time: snap.Time, Value: v})
+		}
+	}
+	return points
+}
+
+// ParseWindow parses a look-back window such as "90m", "24h", "7d" or "2w"
+func ParseWindow(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	for suffix, unit := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if n, ok := strings.CutSuffix(s, suffix); ok {
+			v, err := strconv.ParseFloat(n, 64)
+			if err != nil || v < 0 {
+				return 0, fmt.Errorf("invalid window %q", s)
+			}
+			return time.Duration(v * float64(unit)), nil
+		}
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid window %q (use e.g. 90m, 24h, 7d, 2w)", s)
+	}
+	return d, nil
+}
@@ -0,0 +1,98 @@
+package stats
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/sovereynai/reign/internal/client"
+	"github.com/sovereynai/reign/internal/config"
+)
+
+// Snapshot is a dashboard stats sample recorded at a point in time
+type Snapshot struct {
+	Time  time.Time              `json:"time"`
+	Stats *client.DashboardStats `json:"stats"`
+}
+
+// Store is an append-only JSON Lines file of snapshots
+type Store struct {
+	Path string
+}
+
+// DefaultStore returns the store under the sovereyn home
+func DefaultStore() *Store {
+	return NewStore(filepath.Join(config.Home(), "stats", "history.jsonl"))
+}
+
+// NewStore creates a store backed by the given file
+func NewStore(path string) *Store {
+	return &Store{Path: path}
+}
+
+// Record appends the stats as a new snapshot taken now
+func (s *Store) Record(stats *client.DashboardStats) (*Snapshot, error) {
+	snap := &Snapshot{Time: time.Now().UTC(), Stats: stats}
+	if err := s.Append(snap); err != nil {
+		return nil, err
+	}
+	return snap, nil
+}
+
+// Append writes a snapshot to the end of the store
+func (s *Store) Append(snap *Snapshot) error {
+	line, err := json.Marshal(snap)
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.Path), 0755); err != nil {
+		return fmt.Errorf("failed to create stats directory: %w", err)
+	}
+
+	f, err := os.OpenFile(s.Path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open stats history: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return nil
+}
+
+// Since returns all snapshots taken at or after t, oldest first. Lines that
+// cannot be decoded (e.g. a partial write) are skipped.
+func (s *Store) Since(t time.Time) ([]Snapshot, error) {
+	f, err := os.Open(s.Path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open stats history: %w", err)
+	}
+	defer f.Close()
+
+	var snaps []Snapshot
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		var snap Snapshot
+		if err := json.Unmarshal(scanner.Bytes(), &snap); err != nil || snap.Stats == nil {
+			continue
+		}
+		if snap.Time.Before(t) {
+			continue
+		}
+		snaps = append(snaps, snap)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read stats history: %w", err)
+	}
+
+	return snaps, nil
+}
@@ -0,0 +1,158 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// MaxOutput limits how much of a tool's output is given to the model
+const MaxOutput = 16 * 1024
+
+var placeholder = regexp.MustCompile(`\{\{\s*([a-zA-Z0-9_-]+)\s*\}\}`)
+
+// Run calls the tool with the model's arguments and returns its output
+func (s Spec) Run(ctx context.Context, args map[string]any) (string, error) {
+	timeout := s.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	if args == nil {
+		args = map[string]any{}
+	}
+
+	var out string
+	var err error
+	if s.HTTP != nil {
+		out, err = s.runHTTP(ctx, args)
+	} else {
+		out, err = s.runCommand(ctx, args)
+	}
+	if ctx.Err() == context.DeadlineExceeded {
+		return "", fmt.Errorf("%s timed out after %s", s.Name, timeout)
+	}
+	return truncate(out), err
+}
+
+func (s Spec) runCommand(ctx context.Context, args map[string]any) (string, error) {
+	argv := make([]string, len(s.Command))
+	for i, a := range s.Command {
+		argv[i] = expand(a, args, nil)
+	}
+
+	input, err := json.Marshal(args)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode arguments: %w", err)
+	}
+
+	cmd := exec.CommandContext(ctx, argv[0], argv[1:]...)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Env = os.Environ()
+	for _, name := range slices.Sorted(maps.Keys(args)) {
+		key := "TOOL_ARG_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+		cmd.Env = append(cmd.Env, key+"="+format(args[name]))
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return stdout.String(), fmt.Errorf("%s failed: %w: %s", s.Name, err, truncate(msg))
+		}
+		return stdout.String(), fmt.Errorf("%s failed: %w", s.Name, err)
+	}
+	return stdout.String(), nil
+}
+
+func (s Spec) runHTTP(ctx context.Context, args map[string]any) (string, error) {
+	target := os.ExpandEnv(s.HTTP.URL)
+	path, query, hasQuery := strings.Cut(target, "?")
+	target = expand(path, args, url.PathEscape)
+	if hasQuery {
+		target += "?" + expand(query, args, url.QueryEscape)
+	}
+
+	var body io.Reader
+	switch s.HTTP.Method {
+	case http.MethodGet, http.MethodHead, http.MethodDelete:
+	default:
+		data, err := json.Marshal(args)
+		if err != nil {
+			return "", fmt.Errorf("failed to encode arguments: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, s.HTTP.Method, target, body)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for k, v := range s.HTTP.Headers {
+		req.Header.Set(k, os.ExpandEnv(v))
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("%s request failed: %w", s.Name, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, MaxOutput+1))
+	if err != nil {
+		return "", fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode >= 400 {
+		if msg := strings.TrimSpace(string(data)); msg != "" {
+			return "", fmt.Errorf("%s returned %s: %s", s.Name, resp.Status, truncate(msg))
+		}
+		return "", fmt.Errorf("%s returned %s", s.Name, resp.Status)
+	}
+	return string(data), nil
+}
+
+// expand replaces {{arg}} placeholders with argument values, escaped with
+// escape if it isn't nil. Missing arguments are replaced with nothing.
+func expand(s string, args map[string]any, escape func(string) string) string {
+	return placeholder.ReplaceAllStringFunc(s, func(m string) string {
+		v, ok := args[placeholder.FindStringSubmatch(m)[1]]
+		if !ok {
+			return ""
+		}
+		if escape != nil {
+			return escape(format(v))
+		}
+		return format(v)
+	})
+}
+
+// format renders an argument as text: strings as they are, anything else as JSON
+func format(v any) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	data, _ := json.Marshal(v)
+	return string(data)
+}
+
+func truncate(s string) string {
+	if len(s) <= MaxOutput {
+		return s
+	}
+	return s[:MaxOutput] + "\n... (output truncated)"
+}
@@ -0,0 +1,136 @@
+// Package tools loads tools a model may call during a chat from a YAML file.
+// Each tool runs a local command or sends an HTTP request, and its output is
+// given back to the model.
+package tools
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/sovereynai/reign/internal/client"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultTimeout limits how long a tool may run when it sets no timeout
+const DefaultTimeout = 30 * time.Second
+
+var namePattern = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,64}$`)
+
+// Spec is one tool in a tools file
+type Spec struct {
+	Name        string         `yaml:"name"`
+	Description string         `yaml:"description"`
+	Parameters  map[string]any `yaml:"parameters"` // JSON Schema of the arguments
+
+	// Command is the program and arguments to run, without a shell.
+	// {{arg}} is replaced with the argument's value.
+	Command []string `yaml:"command"`
+
+	// HTTP is the request to send, instead of a command
+	HTTP *HTTPSpec `yaml:"http"`
+
+	Timeout time.Duration `yaml:"timeout"`
+	Confirm *bool         `yaml:"confirm"` // Ask before each call; defaults to true
+}
+
+// HTTPSpec is the request an HTTP tool sends. ${VAR} in the URL and headers
+// is replaced with the environment variable, and {{arg}} in the URL with the
+// escaped argument. Arguments are sent as a JSON body for methods other than
+// GET, HEAD and DELETE.
+type HTTPSpec struct {
+	Method  string            `yaml:"method"` // Defaults to GET
+	URL     string            `yaml:"url"`
+	Headers map[string]string `yaml:"headers"`
+}
+
+// NeedsConfirm reports whether the user should approve each call
+func (s Spec) NeedsConfirm() bool {
+	return s.Confirm == nil || *s.Confirm
+}
+
+// Definition describes the tool to a model
+func (s Spec) Definition() client.Tool {
+	params := s.Parameters
+	if params == nil {
+		params = map[string]any{"type": "object", "properties": map[string]any{}}
+	}
+	return client.Tool{
+		Type:     "function",
+		Function: client.ToolFunction{Name: s.Name, Description: s.Description, Parameters: params},
+	}
+}
+
+// Load reads and checks a tools file
+func Load(path string) ([]Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tools file: %w", err)
+	}
+
+	var file struct {
+		Tools []Spec `yaml:"tools"`
+	}
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if len(file.Tools) == 0 {
+		return nil, fmt.Errorf("%s has no tools", path)
+	}
+
+	var names []string
+	for i := range file.Tools {
+		s := &file.Tools[i]
+		if err := s.check(); err != nil {
+			return nil, fmt.Errorf("%s: tool %d: %w", path, i+1, err)
+		}
+		if slices.Contains(names, s.Name) {
+			return nil, fmt.Errorf("%s: duplicate tool %q", path, s.Name)
+		}
+		names = append(names, s.Name)
+	}
+	return file.Tools, nil
+}
+
+// Find returns the tool with the given name
+func Find(specs []Spec, name string) (Spec, bool) {
+	i := slices.IndexFunc(specs, func(s Spec) bool { return s.Name == name })
+	if i < 0 {
+		return Spec{}, false
+	}
+	return specs[i], true
+}
+
+func (s *Spec) check() error {
+	if !namePattern.MatchString(s.Name) {
+		return fmt.Errorf("invalid name %q (letters, digits, _ and - only)", s.Name)
+	}
+	switch {
+	case len(s.Command) > 0 && s.HTTP != nil:
+		return fmt.Errorf("%s: give either command or http, not both", s.Name)
+	case len(s.Command) > 0:
+		if s.Command[0] == "" {
+			return fmt.Errorf("%s: command has no program", s.Name)
+		}
+	case s.HTTP != nil:
+		if s.HTTP.URL == "" {
+			return fmt.Errorf("%s: http has no url", s.Name)
+		}
+		s.HTTP.Method = strings.ToUpper(s.HTTP.Method)
+		if s.HTTP.Method == "" {
+			s.HTTP.Method = "GET"
+		}
+	default:
+		return fmt.Errorf("%s: needs a command or http", s.Name)
+	}
+	if s.Parameters != nil && s.Parameters["type"] != "object" {
+		return fmt.Errorf(`%s: parameters must be a JSON Schema with type "object"`, s.Name)
+	}
+	if s.Timeout < 0 {
+		return fmt.Errorf("%s: timeout must be positive", s.Name)
+	}
+	return nil
+}
@@ -0,0 +1,277 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Config selects where spans are exported. Tracing stays disabled when
+// both Endpoint and File are empty.
+type Config struct {
+	Endpoint    string // OTLP/HTTP collector base URL, e.g. http://localhost:4318
+	File        string // Append OTLP/JSON lines to this file
+	ServiceName string // Defaults to "reign"
+	Version     string
+	Parent      string // W3C traceparent of a calling process, if any
+}
+
+// Init enables tracing according to cfg
+func Init(cfg Config) error {
+	if cfg.Endpoint == "" && cfg.File == "" {
+		return nil
+	}
+	if cfg.ServiceName == "" {
+		cfg.ServiceName = "reign"
+	}
+
+	var exp spanExporter
+	if cfg.File != "" {
+		if err := os.MkdirAll(filepath.Dir(cfg.File), 0755); err != nil {
+			return fmt.Errorf("failed to create trace directory: %w", err)
+		}
+		exp = &fileExporter{path: cfg.File, resource: resourceFor(cfg)}
+	} else {
+		endpoint := strings.TrimRight(cfg.Endpoint, "/")
+		if !strings.HasSuffix(endpoint, "/v1/traces") {
+			endpoint += "/v1/traces"
+		}
+		exp = &httpExporter{
+			url:      endpoint,
+			client:   &http.Client{Timeout: 5 * time.Second},
+			resource: resourceFor(cfg),
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	exporter = exp
+	if p, ok := parseTraceparent(cfg.Parent); ok {
+		remote = p
+	}
+	return nil
+}
+
+// RootSpan returns the command's root span, or nil
+func RootSpan() *Span {
+	mu.Lock()
+	defer mu.Unlock()
+	return root
+}
+
+// Shutdown ends the root span and exports every remaining span
+func Shutdown(ctx context.Context) error {
+	RootSpan().End()
+
+	mu.Lock()
+	batch, exp := pending, exporter
+	pending = nil
+	mu.Unlock()
+	if exp == nil {
+		return nil
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		flushing.Wait()
+		if len(batch) == 0 {
+			done <- nil
+			return
+		}
+		done <- exp.export(batch)
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("timed out exporting traces: %w", ctx.Err())
+	}
+}
+
+// spanExporter sends a batch of ended spans somewhere
+type spanExporter interface {
+	export(spans []*Span) error
+}
+
+type httpExporter struct {
+	url      string
+	client   *http.Client
+	resource otlpResource
+}
+
+func (e *httpExporter) export(spans []*Span) error {
+	body, err := json.Marshal(exportRequest(e.resource, spans))
+	if err != nil {
+		return fmt.Errorf("failed to encode traces: %w", err)
+	}
+	resp, err := e.client.Post(e.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to export traces: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("failed to export traces: collector returned %s", resp.Status)
+	}
+	return nil
+}
+
+// fileExporter appends one OTLP/JSON export request per line, the format
+// read by the collector's otlpjsonfile receiver
+type fileExporter struct {
+	mu       sync.Mutex
+	path     string
+	resource otlpResource
+}
+
+func (e *fileExporter) export(spans []*Span) error {
+	line, err := json.Marshal(exportRequest(e.resource, spans))
+	if err != nil {
+		return fmt.Errorf("failed to encode traces: %w", err)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	f, err := os.OpenFile(e.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open trace file: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write traces: %w", err)
+	}
+	return nil
+}
+
+// OTLP/JSON encoding of ExportTraceServiceRequest
+
+type otlpRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpAttribute `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope struct {
+		Name string `json:"name"`
+	} `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpSpan struct {
+	TraceID           string          `json:"traceId"`
+	SpanID            string          `json:"spanId"`
+	ParentSpanID      string          `json:"parentSpanId,omitempty"`
+	Name              string          `json:"name"`
+	Kind              int             `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []otlpAttribute `json:"attributes,omitempty"`
+	Events            []otlpEvent     `json:"events,omitempty"`
+	Status            otlpStatus      `json:"status"`
+}
+
+type otlpEvent struct {
+	TimeUnixNano string `json:"timeUnixNano"`
+	Name         string `json:"name"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code,omitempty"` // 0 unset, 2 error
+	Message string `json:"message,omitempty"`
+}
+
+type otlpAttribute struct {
+	Key   string         `json:"key"`
+	Value map[string]any `json:"value"`
+}
+
+func resourceFor(cfg Config) otlpResource {
+	attrs := map[string]any{"service.name": cfg.ServiceName}
+	if cfg.Version != "" {
+		attrs["service.version"] = cfg.Version
+	}
+	if host, err := os.Hostname(); err == nil {
+		attrs["host.name"] = host
+	}
+	return otlpResource{Attributes: toAttributes(attrs)}
+}
+
+func exportRequest(resource otlpResource, spans []*Span) otlpRequest {
+	scope := otlpScopeSpans{}
+	scope.Scope.Name = "github.com/sovereynai/reign"
+	for _, s := range spans {
+		scope.Spans = append(scope.Spans, s.toOTLP())
+	}
+	return otlpRequest{ResourceSpans: []otlpResourceSpans{{Resource: resource, ScopeSpans: []otlpScopeSpans{scope}}}}
+}
+
+func (s *Span) toOTLP() otlpSpan {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := otlpSpan{
+		TraceID:           hex.EncodeToString(s.TraceID[:]),
+		SpanID:            hex.EncodeToString(s.SpanID[:]),
+		Name:              s.Name,
+		Kind:              s.Kind,
+		StartTimeUnixNano: strconv.FormatInt(s.Start.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(s.end.UnixNano(), 10),
+		Attributes:        toAttributes(s.attributes),
+	}
+	if s.ParentID != [8]byte{} {
+		out.ParentSpanID = hex.EncodeToString(s.ParentID[:])
+	}
+	for _, e := range s.events {
+		out.Events = append(out.Events, otlpEvent{TimeUnixNano: strconv.FormatInt(e.time.UnixNano(), 10), Name: e.name})
+	}
+	if s.errMsg != "" {
+		out.Status = otlpStatus{Code: 2, Message: s.errMsg}
+	}
+	return out
+}
+
+func toAttributes(attrs map[string]any) []otlpAttribute {
+	keys := make([]string, 0, len(attrs))
+	for k := range attrs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	out := make([]otlpAttribute, 0, len(keys))
+	for _, k := range keys {
+		var value map[string]any
+		switch v := attrs[k].(type) {
+		case string:
+			value = map[string]any{"stringValue": v}
+		case bool:
+			value = map[string]any{"boolValue": v}
+		case int:
+			value = map[string]any{"intValue": strconv.Itoa(v)}
+		case int64:
+			value = map[string]any{"intValue": strconv.FormatInt(v, 10)}
+		case float64:
+			value = map[string]any{"doubleValue": v}
+		default:
+			value = map[string]any{"stringValue": fmt.Sprint(v)}
+		}
+		out = append(out, otlpAttribute{Key: k, Value: value})
+	}
+	return out
+}
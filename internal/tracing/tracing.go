@@ -0,0 +1,208 @@
+// Package tracing records OpenTelemetry-compatible spans for reign commands
+// and exports them as OTLP/JSON to a collector or a file. Tracing is off
+// unless Init is given an endpoint or file; spans are then nil and free.
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Span kinds, as numbered by OTLP
+const (
+	KindInternal = 1
+	KindClient   = 3
+)
+
+// Span is a timed operation in a trace. A nil *Span is valid and ignores
+// every call, which is what Start returns while tracing is disabled.
+type Span struct {
+	TraceID  [16]byte
+	SpanID   [8]byte
+	ParentID [8]byte
+	Name     string
+	Kind     int
+	Start    time.Time
+
+	mu         sync.Mutex
+	end        time.Time
+	attributes map[string]any
+	events     []spanEvent
+	errMsg     string
+	ended      bool
+}
+
+type spanEvent struct {
+	name string
+	time time.Time
+}
+
+type spanKey struct{}
+
+var (
+	mu       sync.Mutex
+	exporter spanExporter // Nil while tracing is disabled
+	pending  []*Span
+	root     *Span // Parent for spans started without one in context
+	remote   *Span // Parent from TRACEPARENT, if the caller is traced
+	flushing sync.WaitGroup
+)
+
+// batchSize is how many ended spans are buffered before exporting
+const batchSize = 64
+
+// Enabled reports whether spans are being recorded
+func Enabled() bool {
+	mu.Lock()
+	defer mu.Unlock()
+	return exporter != nil
+}
+
+// Start begins a span as a child of the span in ctx, or of the command's
+// root span if ctx has none. Call End on the returned span.
+func Start(ctx context.Context, name string, kind ...int) (context.Context, *Span) {
+	mu.Lock()
+	enabled := exporter != nil
+	parent := root
+	if parent == nil {
+		parent = remote
+	}
+	mu.Unlock()
+	if !enabled {
+		return ctx, nil
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if p := FromContext(ctx); p != nil {
+		parent = p
+	}
+
+	s := &Span{Name: name, Kind: KindInternal, Start: time.Now()}
+	if len(kind) > 0 {
+		s.Kind = kind[0]
+	}
+	if parent != nil {
+		s.TraceID = parent.TraceID
+		s.ParentID = parent.SpanID
+	} else {
+		rand.Read(s.TraceID[:])
+	}
+	rand.Read(s.SpanID[:])
+
+	return context.WithValue(ctx, spanKey{}, s), s
+}
+
+// StartRoot begins the span that covers a whole command. Spans started
+// without a parent in their context become its children.
+func StartRoot(name string) *Span {
+	_, s := Start(context.Background(), name)
+	if s != nil {
+		mu.Lock()
+		root = s
+		mu.Unlock()
+	}
+	return s
+}
+
+// FromContext returns the span stored in ctx, if any
+func FromContext(ctx context.Context) *Span {
+	if ctx == nil {
+		return nil
+	}
+	s, _ := ctx.Value(spanKey{}).(*Span)
+	return s
+}
+
+// SetAttr records a key/value attribute on the span
+func (s *Span) SetAttr(key string, value any) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.attributes == nil {
+		s.attributes = map[string]any{}
+	}
+	s.attributes[key] = value
+}
+
+// AddEvent marks a point in time within the span
+func (s *Span) AddEvent(name string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, spanEvent{name: name, time: time.Now()})
+}
+
+// SetError marks the span as failed
+func (s *Span) SetError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.errMsg = err.Error()
+}
+
+// End finishes the span and queues it for export. Only the first call counts.
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.end = time.Now()
+	s.mu.Unlock()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if exporter == nil {
+		return
+	}
+	pending = append(pending, s)
+	if len(pending) >= batchSize {
+		batch, exp := pending, exporter
+		pending = nil
+		flushing.Add(1)
+		go func() {
+			defer flushing.Done()
+			exp.export(batch)
+		}()
+	}
+}
+
+// Traceparent formats the span as a W3C trace context header value
+func (s *Span) Traceparent() string {
+	if s == nil {
+		return ""
+	}
+	return fmt.Sprintf("00-%s-%s-01", hex.EncodeToString(s.TraceID[:]), hex.EncodeToString(s.SpanID[:]))
+}
+
+// parseTraceparent reads a W3C traceparent header into a remote parent span
+func parseTraceparent(header string) (*Span, bool) {
+	parts := strings.Split(strings.TrimSpace(header), "-")
+	if len(parts) != 4 || len(parts[1]) != 32 || len(parts[2]) != 16 {
+		return nil, false
+	}
+	s := &Span{}
+	if _, err := hex.Decode(s.TraceID[:], []byte(parts[1])); err != nil {
+		return nil, false
+	}
+	if _, err := hex.Decode(s.SpanID[:], []byte(parts[2])); err != nil {
+		return nil, false
+	}
+	return s, true
+}
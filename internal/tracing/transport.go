@@ -0,0 +1,75 @@
+package tracing
+
+import (
+	"errors"
+	"io"
+	"net/http"
+)
+
+// Transport wraps base so every request gets a client span and carries W3C
+// trace context to the server. The span stays open until the response body
+// is closed, so streamed responses are timed to the end. Without tracing
+// enabled, requests pass through untouched.
+func Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{base: base}
+}
+
+type transport struct {
+	base http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := Start(req.Context(), "HTTP "+req.Method+" "+req.URL.Path, KindClient)
+	if span == nil {
+		return t.base.RoundTrip(req)
+	}
+	span.SetAttr("http.request.method", req.Method)
+	span.SetAttr("url.full", req.URL.Redacted())
+	span.SetAttr("server.address", req.URL.Host)
+
+	// RoundTrippers must not modify the caller's request
+	req = req.Clone(ctx)
+	req.Header.Set("traceparent", span.Traceparent())
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		span.SetError(err)
+		span.End()
+		return nil, err
+	}
+	span.AddEvent("response_headers")
+	span.SetAttr("http.response.status_code", resp.StatusCode)
+	if resp.StatusCode >= 400 {
+		span.SetError(errors.New(resp.Status))
+	}
+	resp.Body = &tracedBody{ReadCloser: resp.Body, span: span}
+	return resp, nil
+}
+
+// tracedBody marks the first byte of the body and ends the span on close
+type tracedBody struct {
+	io.ReadCloser
+	span      *Span
+	firstByte bool
+	bytes     int64
+}
+
+func (b *tracedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if n > 0 && !b.firstByte {
+		b.firstByte = true
+		b.span.AddEvent("first_byte")
+	}
+	b.bytes += int64(n)
+	return n, err
+}
+
+func (b *tracedBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.span.SetAttr("http.response.body.size", b.bytes)
+	b.span.End()
+	return err
+}
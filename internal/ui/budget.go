@@ -0,0 +1,63 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sovereynai/reign/internal/budget"
+	"github.com/sovereynai/reign/internal/client"
+)
+
+// RenderBudget renders today's spending against each budget. Account credits
+// are shown when available; credits may be nil.
+func RenderBudget(usage []budget.Usage, perRequest float64, credits *client.CreditStats) string {
+	d := dashboard{
+		Title:    WithIcon(Icon.Credits, "REIGN - Spending Budgets"),
+		Subtitle: "today, this machine",
+	}
+
+	var body strings.Builder
+	if len(usage) == 0 && perRequest <= 0 {
+		body.WriteString(mutedStyle.Render("  No budgets set. Try: reign budget set daily 5") + "\n")
+	}
+	for _, u := range usage {
+		left := max(0, u.Limit-u.Spent)
+		body.WriteString(fmt.Sprintf("  %s %s %s %s\n",
+			labelStyle.Render(fmt.Sprintf("%-28s", u.Label())),
+			renderProgressBar(u.Fraction(), 10),
+			valueStyle.Render(fmt.Sprintf("%-20s", fmt.Sprintf("%.4f / %.4f", u.Spent, u.Limit))),
+			budgetStyle(u.Fraction()).Render(fmt.Sprintf("%.4f left", left)),
+		))
+	}
+	if perRequest > 0 {
+		body.WriteString(fmt.Sprintf("  %s %s\n",
+			labelStyle.Render(fmt.Sprintf("%-28s", "Per request")),
+			valueStyle.Render(fmt.Sprintf("max %.4f credits", perRequest))))
+	}
+	d.Sections = append(d.Sections, section{
+		ID:    "budgets",
+		Title: WithIcon(Icon.Target, "BUDGETS"),
+		Body:  body.String(),
+	})
+
+	if credits != nil {
+		d.Sections = append(d.Sections, section{
+			ID:    "credits",
+			Title: WithIcon(Icon.Chart, "ACCOUNT"),
+			Body:  renderCredits(credits),
+		})
+	}
+
+	return d.render()
+}
+
+func budgetStyle(fraction float64) lipgloss.Style {
+	switch {
+	case fraction >= 1:
+		return errorStyle
+	case fraction >= 0.8:
+		return warningStyle
+	}
+	return successStyle
+}
@@ -0,0 +1,162 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/sovereynai/reign/internal/client"
+)
+
+// Trends holds recorded history for the dashboard charts, oldest first. A nil
+// *Trends or an empty field leaves the corresponding chart out.
+type Trends struct {
+	BurnRate        []float64
+	AvgLatencyMs    []float64
+	QueueDepth      []float64
+	EarningsPerDay  []Bucket
+	RequestsPerHour []Bucket
+}
+
+// Bucket is a labelled value in a bar or column chart
+type Bucket struct {
+	Label string
+	Value float64
+}
+
+// Sparkline renders values as a single line of block characters scaled
+// between their minimum and maximum. Longer series are averaged down to
+// width characters. Fewer than two values render as an empty string.
+func Sparkline(values []float64, width int) string {
+	if len(values) < 2 || width < 1 {
+		return ""
+	}
+	values = downsample(values, width)
+
+	low, high := values[0], values[0]
+	for _, v := range values {
+		low = math.Min(low, v)
+		high = math.Max(high, v)
+	}
+
+	levels := Icon.Spark
+	var out strings.Builder
+	for _, v := range values {
+		i := len(levels) / 2
+		if high > low {
+			i = int((v - low) / (high - low) * float64(len(levels)-1))
+		}
+		out.WriteRune(levels[i])
+	}
+	return out.String()
+}
+
+func downsample(values []float64, width int) []float64 {
+	if len(values) <= width {
+		return values
+	}
+	out := make([]float64, width)
+	for i := range out {
+		start := i * len(values) / width
+		end := (i + 1) * len(values) / width
+		sum := 0.0
+		for _, v := range values[start:end] {
+			sum += v
+		}
+		out[i] = sum / float64(end-start)
+	}
+	return out
+}
+
+// renderBarChart renders one horizontal bar per bucket, scaled to the
+// largest value, followed by the formatted value
+func renderBarChart(buckets []Bucket, width int, format func(float64) string) string {
+	peak := 0.0
+	labelWidth := 0
+	for _, b := range buckets {
+		peak = math.Max(peak, b.Value)
+		labelWidth = max(labelWidth, len(b.Label))
+	}
+
+	var out strings.Builder
+	for _, b := range buckets {
+		filled := 0
+		if peak > 0 {
+			filled = int(math.Round(b.Value / peak * float64(width)))
+		}
+		out.WriteString(fmt.Sprintf("  %s %s%s %s\n",
+			labelStyle.Render(fmt.Sprintf("%-*s", labelWidth, b.Label)),
+			infoStyle.Render(strings.Repeat(string(Icon.BarFull), filled)),
+			strings.Repeat(" ", width-filled),
+			mutedStyle.Render(format(b.Value)),
+		))
+	}
+	return out.String()
+}
+
+// renderColumnChart renders buckets as vertical columns height rows tall,
+// with the label of every labelEvery-th bucket underneath. The tallest
+// column is highlighted.
+func renderColumnChart(buckets []Bucket, height, labelEvery int) string {
+	peak, peakIdx := 0.0, -1
+	for i, b := range buckets {
+		if b.Value > peak {
+			peak, peakIdx = b.Value, i
+		}
+	}
+
+	levels := Icon.Spark
+	steps := float64(height * len(levels))
+
+	var out strings.Builder
+	for row := 0; row < height; row++ {
+		out.WriteString("  ")
+		floor := float64((height - 1 - row) * len(levels))
+		for i, b := range buckets {
+			cell := " "
+			if peak > 0 {
+				level := int(math.Round(b.Value/peak*steps - floor))
+				if level > 0 {
+					cell = string(levels[min(level, len(levels))-1])
+				}
+			}
+			if i == peakIdx {
+				cell = warningStyle.Render(cell)
+			} else {
+				cell = infoStyle.Render(cell)
+			}
+			out.WriteString(cell + " ")
+		}
+		out.WriteString("\n")
+	}
+
+	// Labels, two cells per column
+	var labels strings.Builder
+	for i := 0; i < len(buckets); i += labelEvery {
+		labels.WriteString(fmt.Sprintf("%-*s", labelEvery*2, buckets[i].Label))
+	}
+	out.WriteString("  " + mutedStyle.Render(strings.TrimRight(labels.String(), " ")) + "\n")
+
+	return out.String()
+}
+
+func renderLatencyHistogram(p *client.PerformanceStats) string {
+	return renderBarChart([]Bucket{
+		{Label: "avg", Value: float64(p.AvgLatencyMs)},
+		{Label: "p50", Value: float64(p.P50LatencyMs)},
+		{Label: "p95", Value: float64(p.P95LatencyMs)},
+		{Label: "p99", Value: float64(p.P99LatencyMs)},
+	}, 30, func(v float64) string { return fmt.Sprintf("%.0fms", v) })
+}
+
+// renderTrendLine renders a labelled sparkline, or nothing without history
+func renderTrendLine(label string, values []float64) string {
+	spark := Sparkline(values, 30)
+	if spark == "" {
+		return ""
+	}
+	return fmt.Sprintf("  %s %s %s\n",
+		labelStyle.Render(label),
+		infoStyle.Render(spark),
+		mutedStyle.Render(fmt.Sprintf("(%d samples)", len(values))))
+}
@@ -10,170 +10,241 @@ import (
 
 var (
 	// Box styles
+	borderStyle       lipgloss.Style
+	headerStyle       lipgloss.Style
+	sectionTitleStyle lipgloss.Style
+
+	// Data styles
+	labelStyle   lipgloss.Style
+	valueStyle   lipgloss.Style
+	mutedStyle   lipgloss.Style
+	successStyle lipgloss.Style
+	warningStyle lipgloss.Style
+	errorStyle   lipgloss.Style
+	infoStyle    lipgloss.Style
+
+	// Table styles
+	tableHeaderStyle lipgloss.Style
+	tableCellStyle   lipgloss.Style
+)
+
+func init() {
+	buildStyles()
+}
+
+// buildStyles derives all dashboard styles from the current theme and glyphs
+func buildStyles() {
+	t := currentTheme
+
 	borderStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("240")).
-			Padding(0, 1)
+		Border(Icon.Border).
+		BorderForeground(t.Border).
+		Padding(0, 1)
 
 	headerStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(lipgloss.Color("205")).
-			Background(lipgloss.Color("235")).
-			Padding(0, 1).
-			Width(70)
+		Bold(true).
+		Foreground(t.Primary).
+		Background(t.HeaderBg).
+		Padding(0, 1).
+		Width(70)
 
 	sectionTitleStyle = lipgloss.NewStyle().
-				Bold(true).
-				Foreground(lipgloss.Color("39")).
-				MarginTop(1)
+		Bold(true).
+		Foreground(t.Accent).
+		MarginTop(1)
 
-	// Data styles
 	labelStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("86"))
+		Foreground(t.Label)
 
 	valueStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("255")).
-			Bold(true)
+		Foreground(t.Value).
+		Bold(true)
 
 	mutedStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("243"))
+		Foreground(t.Muted)
 
 	successStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("42"))
+		Foreground(t.Success)
 
 	warningStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("220"))
+		Foreground(t.Warning)
 
 	errorStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("196"))
+		Foreground(t.Error)
 
 	infoStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("117"))
+		Foreground(t.Info)
 
-	// Table styles
 	tableHeaderStyle = lipgloss.NewStyle().
-				Bold(true).
-				Foreground(lipgloss.Color("86"))
+		Bold(true).
+		Foreground(t.Label)
 
 	tableCellStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("255"))
-)
+		Foreground(t.Value)
+}
 
-// RenderDeveloperDashboard renders the AI Developer dashboard
-func RenderDeveloperDashboard(stats *client.DashboardStats) string {
-	var out strings.Builder
+// section is one titled block of a dashboard
+type section struct {
+	ID    string // Stable ID used by layout settings
+	Title string
+	Body  string
+}
 
-	// Header
-	out.WriteString(renderHeader("👑 REIGN - AI Developer Dashboard", stats.Version.Version))
-	out.WriteString("\n\n")
+// dashboard holds the content of a dashboard independent of output format
+type dashboard struct {
+	Title    string
+	Subtitle string
+	Sections []section
+	Actions  []string
+}
 
-	dev := stats.Developer
+// RenderDeveloperDashboard renders the AI Developer dashboard. Charts are
+// drawn from trends when history is available; trends may be nil.
+func RenderDeveloperDashboard(stats *client.DashboardStats, trends *Trends) string {
+	return developerDashboard(stats, trends).render()
+}
 
-	// Inference Metrics
-	out.WriteString(sectionTitleStyle.Render("🤖 INFERENCE METRICS"))
-	out.WriteString("\n")
-	out.WriteString(renderModelTable(dev.Models))
-	out.WriteString("\n")
+// RenderOperatorDashboard renders the Node Operator dashboard. Charts are
+// drawn from trends when history is available; trends may be nil.
+func RenderOperatorDashboard(stats *client.DashboardStats, trends *Trends) string {
+	return operatorDashboard(stats, trends).render()
+}
 
-	// Credits & Usage
-	out.WriteString(sectionTitleStyle.Render("💰 CREDITS & USAGE"))
-	out.WriteString("\n")
-	out.WriteString(renderCredits(&dev.Credits))
-	out.WriteString("\n")
+func developerDashboard(stats *client.DashboardStats, trends *Trends) dashboard {
+	if trends == nil {
+		trends = &Trends{}
+	}
+	dev := stats.Developer
 
-	// Performance
-	out.WriteString(sectionTitleStyle.Render("⚡ PERFORMANCE"))
-	out.WriteString("\n")
-	out.WriteString(renderPerformance(&dev.Performance, &dev.Inference))
-	out.WriteString("\n")
+	d := dashboard{
+		Title:    WithIcon(Icon.Crown, "REIGN - AI Developer Dashboard"),
+		Subtitle: stats.Version.Version,
+	}
+
+	d.Sections = append(d.Sections,
+		section{
+			ID:    "models",
+			Title: WithIcon(Icon.Robot, "INFERENCE METRICS"),
+			Body:  renderModelTable(dev.Models, columnsOrDefault(currentLayout.Developer.ModelColumns, DeveloperModelColumns)),
+		},
+		section{
+			ID:    "credits",
+			Title: WithIcon(Icon.Credits, "CREDITS & USAGE"),
+			Body:  renderCredits(&dev.Credits) + renderTrendLine("Burn Trend:", trends.BurnRate),
+		},
+		section{
+			ID:    "performance",
+			Title: WithIcon(Icon.Speed, "PERFORMANCE"),
+			Body: renderPerformance(&dev.Performance, &dev.Inference) +
+				renderTrendLine("Latency Trend:", trends.AvgLatencyMs) +
+				renderLatencyHistogram(&dev.Performance),
+		},
+	)
 
-	// Smart Insights
 	if len(dev.Insights) > 0 {
-		out.WriteString(sectionTitleStyle.Render("🎯 SMART INSIGHTS"))
-		out.WriteString("\n")
-		out.WriteString(renderInsights(dev.Insights))
-		out.WriteString("\n")
+		d.Sections = append(d.Sections, section{
+			ID:    "insights",
+			Title: WithIcon(Icon.Target, "SMART INSIGHTS"),
+			Body:  renderInsights(dev.Insights),
+		})
 	}
 
-	// Network Health
-	out.WriteString(sectionTitleStyle.Render("📊 NETWORK HEALTH"))
-	out.WriteString("\n")
-	out.WriteString(renderNetwork(&stats.Network))
-	out.WriteString("\n")
+	d.Sections = append(d.Sections, section{
+		ID:    "network",
+		Title: WithIcon(Icon.Chart, "NETWORK HEALTH"),
+		Body:  renderNetwork(&stats.Network) + renderTrendLine("Queue Trend:", trends.QueueDepth),
+	})
 
-	// Quick Actions
-	out.WriteString(renderQuickActions([]string{
+	d.Actions = []string{
 		"reign dev history     - View request history & replay",
 		"reign dev optimize    - Get cost reduction suggestions",
 		"reign dev playground  - Interactive model testing",
 		"reign dev limits      - Check rate limits & quotas",
-	}))
+	}
+	d.arrange(currentLayout.Developer)
 
-	return borderStyle.Render(out.String())
+	return d
 }
 
-// RenderOperatorDashboard renders the Node Operator dashboard
-func RenderOperatorDashboard(stats *client.DashboardStats) string {
-	var out strings.Builder
-
-	// Header
-	out.WriteString(renderHeader("🏛️  THRONE - Node Operator Dashboard", "Uptime: "+stats.Uptime))
-	out.WriteString("\n\n")
-
+func operatorDashboard(stats *client.DashboardStats, trends *Trends) dashboard {
+	if trends == nil {
+		trends = &Trends{}
+	}
 	op := stats.Operator
 
-	// Earnings & Contribution
-	out.WriteString(sectionTitleStyle.Render("💰 EARNINGS & CONTRIBUTION"))
-	out.WriteString("\n")
-	out.WriteString(renderEarnings(&op.Earnings))
-	out.WriteString("\n")
-
-	// Revenue Breakdown
-	out.WriteString(sectionTitleStyle.Render("📈 REVENUE BREAKDOWN"))
-	out.WriteString("\n")
-	out.WriteString(renderRevenueBreakdown(&op.Earnings.Breakdown, op.Earnings.Today))
-	out.WriteString("\n")
-
-	// Workload
-	out.WriteString(sectionTitleStyle.Render("🔥 WORKLOAD (Last 24h)"))
-	out.WriteString("\n")
-	out.WriteString(renderWorkload(&op.Workload))
-	out.WriteString("\n")
-
-	// Hardware Utilization
-	out.WriteString(sectionTitleStyle.Render("🖥️  HARDWARE UTILIZATION"))
-	out.WriteString("\n")
-	out.WriteString(renderHardware(&op.Hardware))
-	out.WriteString("\n")
-
-	// Models Served
-	out.WriteString(sectionTitleStyle.Render("📦 MODELS SERVED"))
-	out.WriteString("\n")
-	out.WriteString(renderModelsServed(op.ModelsServed))
-	out.WriteString("\n")
+	d := dashboard{
+		Title:    WithIcon(Icon.Throne, "THRONE - Node Operator Dashboard"),
+		Subtitle: "Uptime: " + stats.Uptime,
+	}
 
-	// Network Participation
-	out.WriteString(sectionTitleStyle.Render("🌍 NETWORK PARTICIPATION"))
-	out.WriteString("\n")
-	out.WriteString(renderOperatorNetwork(&stats.Network, &op.Reputation))
-	out.WriteString("\n")
+	d.Sections = append(d.Sections,
+		section{
+			ID:    "earnings",
+			Title: WithIcon(Icon.Credits, "EARNINGS & CONTRIBUTION"),
+			Body:  renderEarnings(&op.Earnings) + renderEarningsPerDay(trends.EarningsPerDay),
+		},
+		section{
+			ID:    "revenue",
+			Title: WithIcon(Icon.Growth, "REVENUE BREAKDOWN"),
+			Body:  renderRevenueBreakdown(&op.Earnings.Breakdown, op.Earnings.Today),
+		},
+		section{
+			ID:    "workload",
+			Title: WithIcon(Icon.Fire, "WORKLOAD (Last 24h)"),
+			Body:  renderWorkload(&op.Workload) + renderRequestsPerHour(trends.RequestsPerHour),
+		},
+		section{
+			ID:    "hardware",
+			Title: WithIcon(Icon.Computer, "HARDWARE UTILIZATION"),
+			Body:  renderHardware(&op.Hardware),
+		},
+		section{
+			ID:    "models",
+			Title: WithIcon(Icon.Package, "MODELS SERVED"),
+			Body:  renderModelsServed(op.ModelsServed, columnsOrDefault(currentLayout.Operator.ModelColumns, OperatorModelColumns)),
+		},
+		section{
+			ID:    "network",
+			Title: WithIcon(Icon.Globe, "NETWORK PARTICIPATION"),
+			Body:  renderOperatorNetwork(&stats.Network, &op.Reputation),
+		},
+	)
 
-	// Alerts & Optimization
 	if len(op.Alerts) > 0 {
-		out.WriteString(sectionTitleStyle.Render("⚠️  ALERTS & OPTIMIZATION"))
-		out.WriteString("\n")
-		out.WriteString(renderAlerts(op.Alerts))
-		out.WriteString("\n")
+		d.Sections = append(d.Sections, section{
+			ID:    "alerts",
+			Title: WithIcon(Icon.Alert, "ALERTS & OPTIMIZATION"),
+			Body:  renderAlerts(op.Alerts),
+		})
 	}
 
-	// Quick Actions
-	out.WriteString(renderQuickActions([]string{
+	d.Actions = []string{
 		"reign node earnings   - Detailed revenue breakdown & trends",
 		"reign node optimize   - Hardware tuning recommendations",
 		"reign node models     - Add/remove models based on demand",
 		"reign node peers      - Network connections & health",
 		"reign node logs       - Real-time inference log stream",
-	}))
+	}
+	d.arrange(currentLayout.Operator)
+
+	return d
+}
+
+// render draws the dashboard as a bordered box for the terminal
+func (d dashboard) render() string {
+	var out strings.Builder
+
+	out.WriteString(renderHeader(d.Title, d.Subtitle))
+	out.WriteString("\n\n")
+
+	for _, s := range d.Sections {
+		out.WriteString(sectionTitleStyle.Render(s.Title))
+		out.WriteString("\n")
+		out.WriteString(s.Body)
+		out.WriteString("\n")
+	}
+
+	out.WriteString(renderQuickActions(d.Actions))
 
 	return borderStyle.Render(out.String())
 }
@@ -184,7 +255,7 @@ func renderHeader(title, subtitle string) string {
 	return headerStyle.Render(fmt.Sprintf("%s     %s", title, mutedStyle.Render(subtitle)))
 }
 
-func renderModelTable(models []client.ModelUsage) string {
+func renderModelTable(models []client.ModelUsage, columns []string) string {
 	if len(models) == 0 {
 		return mutedStyle.Render("  No inference activity yet")
 	}
@@ -192,33 +263,31 @@ func renderModelTable(models []client.ModelUsage) string {
 	var out strings.Builder
 
 	// Table header
-	out.WriteString(fmt.Sprintf("  %-15s %-10s %-12s %-10s %-10s\n",
-		tableHeaderStyle.Render("Model"),
-		tableHeaderStyle.Render("Today"),
-		tableHeaderStyle.Render("7d Avg"),
-		tableHeaderStyle.Render("Latency"),
-		tableHeaderStyle.Render("Credits"),
-	))
+	out.WriteString(" ")
+	for _, id := range columns {
+		col := modelUsageColumns[id]
+		out.WriteString(fmt.Sprintf(" %-*s", col.width, tableHeaderStyle.Render(col.header)))
+	}
+	out.WriteString("\n")
 
 	// Table rows
 	for _, m := range models {
-		out.WriteString(fmt.Sprintf("  %-15s %-10s %-12s %-10s %-10s\n",
-			tableCellStyle.Render(m.Name),
-			tableCellStyle.Render(fmt.Sprintf("%d req", m.RequestsToday)),
-			tableCellStyle.Render(fmt.Sprintf("%.1f/day", m.WeekAvg)),
-			tableCellStyle.Render(fmt.Sprintf("%dms", m.AvgLatencyMs)),
-			tableCellStyle.Render(fmt.Sprintf("%.1fc", m.CreditsSpent)),
-		))
+		out.WriteString(" ")
+		for _, id := range columns {
+			col := modelUsageColumns[id]
+			out.WriteString(fmt.Sprintf(" %-*s", col.width, tableCellStyle.Render(col.value(m))))
+		}
+		out.WriteString("\n")
 	}
 
 	return out.String()
 }
 
 func renderCredits(c *client.CreditStats) string {
-	trendIcon := "▼"
+	trendIcon := Icon.TrendDown
 	trendColor := successStyle
 	if c.TrendPercent > 0 {
-		trendIcon = "▲"
+		trendIcon = Icon.TrendUp
 		trendColor = errorStyle
 	}
 
@@ -254,7 +323,7 @@ func renderInsights(insights []string) string {
 	var out strings.Builder
 	for _, insight := range insights {
 		out.WriteString("  ")
-		out.WriteString(infoStyle.Render("→ "))
+		out.WriteString(infoStyle.Render(Icon.Arrow + " "))
 		out.WriteString(insight)
 		out.WriteString("\n")
 	}
@@ -274,10 +343,10 @@ func renderNetwork(n *client.NetworkStats) string {
 }
 
 func renderEarnings(e *client.EarningsStats) string {
-	trendIcon := "▲"
+	trendIcon := Icon.TrendUp
 	trendColor := successStyle
 	if e.WeekTrend < 0 {
-		trendIcon = "▼"
+		trendIcon = Icon.TrendDown
 		trendColor = errorStyle
 	}
 
@@ -335,6 +404,31 @@ func renderWorkload(w *client.WorkloadStats) string {
 	)
 }
 
+func renderEarningsPerDay(days []Bucket) string {
+	if !hasData(days) {
+		return ""
+	}
+	return "\n  " + labelStyle.Render("Earnings per day:") + "\n" +
+		renderBarChart(days, 30, func(v float64) string { return fmt.Sprintf("%.1f credits", v) })
+}
+
+func renderRequestsPerHour(hours []Bucket) string {
+	if !hasData(hours) {
+		return ""
+	}
+	return "\n  " + labelStyle.Render("Requests per hour:") + "\n" +
+		renderColumnChart(hours, 4, 6)
+}
+
+func hasData(buckets []Bucket) bool {
+	for _, b := range buckets {
+		if b.Value > 0 {
+			return true
+		}
+	}
+	return false
+}
+
 func renderHardware(h *client.HardwareStats) string {
 	gpuBar := renderProgressBar(h.GPU.Percent/100.0, 10)
 	cpuBar := renderProgressBar(h.CPU.Percent/100.0, 10)
@@ -359,38 +453,46 @@ func renderHardware(h *client.HardwareStats) string {
 		labelStyle.Render("Disk:"),
 		diskBar, h.Disk.Percent, mutedStyle.Render(fmt.Sprintf("%s / %s", h.Disk.Used, h.Disk.Total)),
 		labelStyle.Render("Temp:"),
-		tempColor.Render(fmt.Sprintf("%.0f°C", h.Temperature.GPU)),
-		tempColor.Render(fmt.Sprintf("%.0f°C", h.Temperature.CPU)),
+		tempColor.Render(fmt.Sprintf("%.0f%s", h.Temperature.GPU, Icon.Degrees)),
+		tempColor.Render(fmt.Sprintf("%.0f%s", h.Temperature.CPU, Icon.Degrees)),
 		mutedStyle.Render(fmt.Sprintf("(%s)", h.Temperature.Status)),
 		labelStyle.Render("Power:"),
 		h.PowerWatts, h.PowerCostDaily,
 	)
 }
 
-func renderModelsServed(models []client.ModelServed) string {
+func renderModelsServed(models []client.ModelServed, columns []string) string {
 	if len(models) == 0 {
 		return mutedStyle.Render("  No models being served")
 	}
 
 	var out strings.Builder
 	for _, m := range models {
-		statusIcon := "✓"
+		statusIcon := Icon.Check
 		statusColor := successStyle
 		if m.Status == "warning" {
-			statusIcon = "⚠"
+			statusIcon = Icon.Warn
 			statusColor = warningStyle
 		} else if m.Status == "idle" {
-			statusIcon = "○"
+			statusIcon = Icon.Idle
 			statusColor = mutedStyle
 		}
 
-		out.WriteString(fmt.Sprintf("  %s %-15s %d reqs  |  Avg: %dms   |  Rev: %.1fc\n",
-			statusColor.Render(statusIcon),
-			m.Name,
-			m.Requests,
-			m.AvgLatencyMs,
-			m.Revenue,
-		))
+		var lead, metrics []string
+		for _, id := range columns {
+			switch id {
+			case "status":
+				lead = append(lead, statusColor.Render(statusIcon))
+			case "name":
+				lead = append(lead, fmt.Sprintf("%-15s", m.Name))
+			default:
+				metrics = append(metrics, modelServedColumns[id](m))
+			}
+		}
+		if len(metrics) > 0 {
+			lead = append(lead, strings.Join(metrics, "  |  "))
+		}
+		out.WriteString("  " + strings.TrimRight(strings.Join(lead, " "), " ") + "\n")
 	}
 	return out.String()
 }
@@ -413,16 +515,16 @@ func renderOperatorNetwork(n *client.NetworkStats, r *client.ReputationStats) st
 func renderAlerts(alerts []client.Alert) string {
 	var out strings.Builder
 	for _, alert := range alerts {
-		icon := "→"
+		icon := Icon.Arrow
 		style := infoStyle
 		if alert.Level == "warning" {
-			icon = "→"
+			icon = Icon.Arrow
 			style = warningStyle
 		} else if alert.Level == "error" {
-			icon = "✗"
+			icon = Icon.Cross
 			style = errorStyle
 		} else if alert.Level == "info" {
-			icon = "✓"
+			icon = Icon.Check
 			style = successStyle
 		}
 
@@ -435,6 +537,10 @@ func renderAlerts(alerts []client.Alert) string {
 }
 
 func renderQuickActions(actions []string) string {
+	if len(actions) == 0 {
+		return ""
+	}
+
 	var out strings.Builder
 	out.WriteString("\n")
 	out.WriteString(mutedStyle.Render("Quick Actions:"))
@@ -458,7 +564,7 @@ func renderProgressBar(percent float64, width int) string {
 	filled := int(percent * float64(width))
 	empty := width - filled
 
-	bar := strings.Repeat("█", filled) + strings.Repeat("░", empty)
+	bar := strings.Repeat(string(Icon.BarFull), filled) + strings.Repeat(string(Icon.BarEmpty), empty)
 
 	var color lipgloss.Style
 	if percent < 0.5 {
@@ -481,11 +587,11 @@ func renderStars(score, maxScore float64) string {
 		emptyStars--
 	}
 
-	stars := strings.Repeat("★", fullStars)
+	stars := strings.Repeat(Icon.StarFull, fullStars)
 	if halfStar {
-		stars += "☆"
+		stars += Icon.StarEmpty
 	}
-	stars += strings.Repeat("☆", emptyStars)
+	stars += strings.Repeat(Icon.StarEmpty, emptyStars)
 
 	return warningStyle.Render(stars)
 }
@@ -0,0 +1,158 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Comparison is one side of a side-by-side comparison of two responses
+type Comparison struct {
+	Title     string
+	Model     string
+	Output    string
+	Error     string
+	LatencyMs int64
+	Cost      float64
+}
+
+// diffRow is one row of a side-by-side diff
+type diffRow struct {
+	left, right string
+	op          byte // '=' same, '~' changed, '-' left only, '+' right only
+}
+
+// RenderComparison renders two responses side by side: their model, latency
+// and cost with the change between them, then a line diff of the outputs
+func RenderComparison(a, b Comparison, width int) string {
+	col := max(20, (width-3)/2)
+	cell := lipgloss.NewStyle().Width(col)
+
+	var out strings.Builder
+	row := func(left, mid, right string) {
+		out.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, cell.Render(left), " "+mid+" ", cell.Render(right)))
+		out.WriteString("\n")
+	}
+
+	row(sectionTitleStyle.UnsetMarginTop().Render(a.Title), " ", sectionTitleStyle.UnsetMarginTop().Render(b.Title))
+	row(labelStyle.Render("Model:   ")+valueStyle.Render(a.Model), " ", labelStyle.Render("Model:   ")+valueStyle.Render(b.Model))
+	row(labelStyle.Render("Latency: ")+valueStyle.Render(fmt.Sprintf("%dms", a.LatencyMs)), " ",
+		labelStyle.Render("Latency: ")+valueStyle.Render(fmt.Sprintf("%dms", b.LatencyMs))+" "+formatDelta(float64(a.LatencyMs), float64(b.LatencyMs)))
+	row(labelStyle.Render("Cost:    ")+valueStyle.Render(fmt.Sprintf("%.4f", a.Cost)), " ",
+		labelStyle.Render("Cost:    ")+valueStyle.Render(fmt.Sprintf("%.4f", b.Cost))+" "+formatDelta(a.Cost, b.Cost))
+	row(labelStyle.Render("Length:  ")+valueStyle.Render(fmt.Sprintf("%d chars", len([]rune(a.Output)))), " ",
+		labelStyle.Render("Length:  ")+valueStyle.Render(fmt.Sprintf("%d chars", len([]rune(b.Output)))))
+	out.WriteString(mutedStyle.Render(strings.Repeat(Icon.Border.Top, col*2+3)))
+	out.WriteString("\n")
+
+	if a.Error != "" || b.Error != "" {
+		row(errorText(a.Error), " ", errorText(b.Error))
+		if a.Error != "" && b.Error != "" {
+			return out.String()
+		}
+	}
+
+	for _, r := range diffLines(splitLines(a.Output), splitLines(b.Output)) {
+		switch r.op {
+		case '=':
+			row(r.left, mutedStyle.Render(Icon.Border.Left), r.right)
+		case '~':
+			row(warningStyle.Render(r.left), warningStyle.Render("~"), warningStyle.Render(r.right))
+		case '-':
+			row(errorStyle.Render(r.left), errorStyle.Render("<"), "")
+		case '+':
+			row("", successStyle.Render(">"), successStyle.Render(r.right))
+		}
+	}
+	if a.Output == b.Output {
+		out.WriteString(successStyle.Render(WithIcon(Icon.Check, "Outputs are identical")))
+		out.WriteString("\n")
+	}
+	return out.String()
+}
+
+// formatDelta describes the change from a to b as a percentage
+func formatDelta(a, b float64) string {
+	if a == 0 {
+		return ""
+	}
+	change := (b - a) / a * 100
+	text := fmt.Sprintf("(%+.0f%%)", change)
+	switch {
+	case change < 0:
+		return successStyle.Render(text)
+	case change > 0:
+		return errorStyle.Render(text)
+	}
+	return mutedStyle.Render(text)
+}
+
+func errorText(err string) string {
+	if err == "" {
+		return ""
+	}
+	return errorStyle.Render(WithIcon(Icon.Fail, err))
+}
+
+func splitLines(s string) []string {
+	s = strings.TrimRight(s, "\n")
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
+
+// diffLines aligns two texts line by line using their longest common
+// subsequence. Runs of removed and added lines are paired up as changes.
+func diffLines(a, b []string) []diffRow {
+	// lcs[i][j] is the LCS length of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var rows []diffRow
+	var removed, added []string
+	flush := func() {
+		for k := 0; k < max(len(removed), len(added)); k++ {
+			switch {
+			case k < len(removed) && k < len(added):
+				rows = append(rows, diffRow{left: removed[k], right: added[k], op: '~'})
+			case k < len(removed):
+				rows = append(rows, diffRow{left: removed[k], op: '-'})
+			default:
+				rows = append(rows, diffRow{right: added[k], op: '+'})
+			}
+		}
+		removed, added = nil, nil
+	}
+
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			flush()
+			rows = append(rows, diffRow{left: a[i], right: b[j], op: '='})
+			i++
+			j++
+		case j < len(b) && (i == len(a) || lcs[i][j+1] >= lcs[i+1][j]):
+			added = append(added, b[j])
+			j++
+		default:
+			removed = append(removed, a[i])
+			i++
+		}
+	}
+	flush()
+	return rows
+}
@@ -0,0 +1,69 @@
+package ui
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDiffLines(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []string
+		want []diffRow
+	}{
+		{name: "both empty"},
+		{
+			name: "same",
+			a:    []string{"x", "y"},
+			b:    []string{"x", "y"},
+			want: []diffRow{{"x", "x", '='}, {"y", "y", '='}},
+		},
+		{
+			name: "all added",
+			b:    []string{"x", "y"},
+			want: []diffRow{{"", "x", '+'}, {"", "y", '+'}},
+		},
+		{
+			name: "all removed",
+			a:    []string{"x", "y"},
+			want: []diffRow{{"x", "", '-'}, {"y", "", '-'}},
+		},
+		{
+			name: "changed line",
+			a:    []string{"x", "old", "z"},
+			b:    []string{"x", "new", "z"},
+			want: []diffRow{{"x", "x", '='}, {"old", "new", '~'}, {"z", "z", '='}},
+		},
+		{
+			name: "inserted line",
+			a:    []string{"x", "z"},
+			b:    []string{"x", "y", "z"},
+			want: []diffRow{{"x", "x", '='}, {"", "y", '+'}, {"z", "z", '='}},
+		},
+		{
+			name: "removed line",
+			a:    []string{"x", "y", "z"},
+			b:    []string{"x", "z"},
+			want: []diffRow{{"x", "x", '='}, {"y", "", '-'}, {"z", "z", '='}},
+		},
+		{
+			name: "uneven change pairs what it can",
+			a:    []string{"x", "a1", "a2", "z"},
+			b:    []string{"x", "b1", "z"},
+			want: []diffRow{{"x", "x", '='}, {"a1", "b1", '~'}, {"a2", "", '-'}, {"z", "z", '='}},
+		},
+		{
+			name: "keeps the longest common lines",
+			a:    []string{"a", "b", "c", "d"},
+			b:    []string{"b", "c", "d", "e"},
+			want: []diffRow{{"a", "", '-'}, {"b", "b", '='}, {"c", "c", '='}, {"d", "d", '='}, {"", "e", '+'}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := diffLines(tt.a, tt.b); !slices.Equal(got, tt.want) {
+				t.Errorf("diffLines() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
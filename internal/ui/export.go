@@ -0,0 +1,212 @@
+package ui
+
+import (
+	"fmt"
+	"html"
+	"html/template"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"github.com/sovereynai/reign/internal/client"
+)
+
+// Export formats
+const (
+	ExportHTML     = "html"
+	ExportSVG      = "svg"
+	ExportMarkdown = "md"
+)
+
+// ExportFormatForPath picks the export format from a file extension
+func ExportFormatForPath(path string) (string, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".html", ".htm":
+		return ExportHTML, nil
+	case ".svg":
+		return ExportSVG, nil
+	case ".md", ".markdown":
+		return ExportMarkdown, nil
+	}
+	return "", fmt.Errorf("unsupported export file %q (use .html, .svg or .md)", path)
+}
+
+// ExportDashboards renders the dashboards matching the stats role as a static
+// report in the given format, without any terminal escape codes
+func ExportDashboards(stats *client.DashboardStats, trends *Trends, format string) (string, error) {
+	var dashboards []dashboard
+	withoutANSI(func() {
+		if stats.Developer != nil && (stats.Role == "developer" || stats.Role == "both") {
+			dashboards = append(dashboards, developerDashboard(stats, trends))
+		}
+		if stats.Operator != nil && (stats.Role == "operator" || stats.Role == "both") {
+			dashboards = append(dashboards, operatorDashboard(stats, trends))
+		}
+	})
+	if len(dashboards) == 0 {
+		return "", fmt.Errorf("no dashboard data to export for role %q", stats.Role)
+	}
+
+	generated := time.Now().Format("2006-01-02 15:04 MST")
+	switch format {
+	case ExportHTML:
+		return exportHTML(dashboards, generated)
+	case ExportSVG:
+		return exportSVG(dashboards, generated), nil
+	case ExportMarkdown:
+		return exportMarkdown(dashboards, generated), nil
+	}
+	return "", fmt.Errorf("unsupported export format %q", format)
+}
+
+// withoutANSI runs f with styling disabled so rendered text is plain
+func withoutANSI(f func()) {
+	prev := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.Ascii)
+	defer lipgloss.SetColorProfile(prev)
+	f()
+}
+
+// bodyLines splits a section body into lines, dropping trailing blank lines
+func bodyLines(body string) []string {
+	lines := strings.Split(body, "\n")
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	return lines
+}
+
+func exportMarkdown(dashboards []dashboard, generated string) string {
+	var out strings.Builder
+	for i, d := range dashboards {
+		if i > 0 {
+			out.WriteString("\n---\n\n")
+		}
+		out.WriteString(fmt.Sprintf("# %s\n\n_%s_\n", d.Title, d.Subtitle))
+		for _, s := range d.Sections {
+			out.WriteString(fmt.Sprintf("\n## %s\n\n```text\n%s\n```\n", s.Title, strings.Join(bodyLines(s.Body), "\n")))
+		}
+		if len(d.Actions) > 0 {
+			out.WriteString("\n**Quick Actions**\n\n")
+			for _, action := range d.Actions {
+				cmd, desc, _ := strings.Cut(action, " - ")
+				out.WriteString(fmt.Sprintf("- `%s` - %s\n", strings.TrimSpace(cmd), strings.TrimSpace(desc)))
+			}
+		}
+	}
+	out.WriteString(fmt.Sprintf("\n_Generated by reign on %s_\n", generated))
+	return out.String()
+}
+
+var htmlReport = template.Must(template.New("report").Funcs(template.FuncMap{
+	"lines": func(body string) string { return strings.Join(bodyLines(body), "\n") },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Sovereyn Report - {{.Generated}}</title>
+<style>
+  body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; background: #f6f7f9; color: #1f2328; margin: 2rem auto; max-width: 56rem; padding: 0 1rem; }
+  .dashboard { background: #fff; border: 1px solid #d0d7de; border-radius: 10px; padding: 1rem 1.5rem; margin-bottom: 2rem; }
+  h1 { font-size: 1.4rem; color: #bf3989; margin: 0; }
+  .subtitle { color: #656d76; margin: 0.25rem 0 1rem; }
+  h2 { font-size: 1rem; color: #0969da; margin: 1.25rem 0 0.5rem; }
+  pre { font-family: ui-monospace, SFMono-Regular, Menlo, Consolas, monospace; font-size: 0.85rem; background: #f6f8fa; border-radius: 6px; padding: 0.75rem; margin: 0; overflow-x: auto; }
+  ul { color: #656d76; font-size: 0.9rem; }
+  code { font-family: ui-monospace, SFMono-Regular, Menlo, Consolas, monospace; }
+  footer { color: #8c959f; font-size: 0.8rem; text-align: center; }
+</style>
+</head>
+<body>
+{{range .Dashboards}}<div class="dashboard">
+  <h1>{{.Title}}</h1>
+  <p class="subtitle">{{.Subtitle}}</p>
+{{range .Sections}}  <h2>{{.Title}}</h2>
+  <pre>{{lines .Body}}</pre>
+{{end}}{{if .Actions}}  <h2>Quick Actions</h2>
+  <ul>{{range .Actions}}<li><code>{{.}}</code></li>{{end}}</ul>
+{{end}}</div>
+{{end}}<footer>Generated by reign on {{.Generated}}</footer>
+</body>
+</html>
+`))
+
+func exportHTML(dashboards []dashboard, generated string) (string, error) {
+	var out strings.Builder
+	err := htmlReport.Execute(&out, struct {
+		Dashboards []dashboard
+		Generated  string
+	}{dashboards, generated})
+	if err != nil {
+		return "", fmt.Errorf("failed to render HTML report: %w", err)
+	}
+	return out.String(), nil
+}
+
+// svgLine is one line of text in an SVG report
+type svgLine struct {
+	text  string
+	class string
+}
+
+func exportSVG(dashboards []dashboard, generated string) string {
+	const (
+		lineHeight = 18
+		charWidth  = 8.4
+		padding    = 24
+	)
+
+	var lines []svgLine
+	for i, d := range dashboards {
+		if i > 0 {
+			lines = append(lines, svgLine{})
+		}
+		lines = append(lines, svgLine{d.Title, "title"}, svgLine{d.Subtitle, "muted"})
+		for _, s := range d.Sections {
+			lines = append(lines, svgLine{}, svgLine{s.Title, "section"})
+			for _, l := range bodyLines(s.Body) {
+				lines = append(lines, svgLine{l, "body"})
+			}
+		}
+		if len(d.Actions) > 0 {
+			lines = append(lines, svgLine{}, svgLine{"Quick Actions:", "muted"})
+			for _, action := range d.Actions {
+				lines = append(lines, svgLine{"  " + action, "muted"})
+			}
+		}
+	}
+	lines = append(lines, svgLine{}, svgLine{"Generated by reign on " + generated, "muted"})
+
+	columns := 0
+	for _, l := range lines {
+		columns = max(columns, lipgloss.Width(l.text))
+	}
+	width := int(float64(columns)*charWidth) + 2*padding
+	height := len(lines)*lineHeight + 2*padding
+
+	var out strings.Builder
+	out.WriteString(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n", width, height, width, height))
+	out.WriteString(`<style>
+  text { font-family: Menlo, Consolas, "DejaVu Sans Mono", monospace; font-size: 14px; white-space: pre; }
+  .title { fill: #bf3989; font-weight: bold; }
+  .section { fill: #0969da; font-weight: bold; }
+  .body { fill: #1f2328; }
+  .muted { fill: #656d76; }
+</style>
+`)
+	out.WriteString(`<rect width="100%" height="100%" rx="10" fill="#ffffff" stroke="#d0d7de"/>` + "\n")
+	for i, l := range lines {
+		if l.text == "" {
+			continue
+		}
+		out.WriteString(fmt.Sprintf(`<text x="%d" y="%d" class="%s" xml:space="preserve">%s</text>`+"\n",
+			padding, padding+(i+1)*lineHeight, l.class, html.EscapeString(l.text)))
+	}
+	out.WriteString("</svg>\n")
+	return out.String()
+}
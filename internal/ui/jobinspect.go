@@ -0,0 +1,303 @@
+package ui
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sovereynai/reign/internal/client"
+)
+
+// Job list orders, cycled with [s]
+var jobSorts = []string{"default", "newest", "longest", "model"}
+
+// Status filters, cycled with [f]; "" shows every job
+var jobStatusFilters = []string{"", "running", "queued", "completed", "failed"}
+
+type jobCancelMsg struct {
+	id  string
+	err error
+}
+
+func newJobFilterInput() textinput.Model {
+	ti := textinput.New()
+	ti.Prompt = "/"
+	ti.Placeholder = "model:llama node:abc or any text"
+	ti.CharLimit = 64
+	ti.Width = 50
+	return ti
+}
+
+// handleKey implements navigation, filtering, sorting and cancelling
+func (m liveJobsModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	key := msg.String()
+	if key == "ctrl+c" {
+		m.quitting = true
+		return m, tea.Quit
+	}
+
+	// Waiting for a cancel confirmation: only "y" proceeds
+	if m.confirmCancel != "" {
+		id := m.confirmCancel
+		m.confirmCancel = ""
+		if key == "y" || key == "Y" {
+			m.notice = "Cancelling job " + ShortID(id) + "..."
+			return m, cancelJob(m.client, id)
+		}
+		m.notice = "Cancel aborted"
+		return m, nil
+	}
+
+	// Editing the filter query
+	if m.filter.Focused() {
+		switch key {
+		case "enter":
+			m.filter.Blur()
+		case "esc":
+			m.filter.Blur()
+			m.filter.SetValue("")
+		default:
+			var cmd tea.Cmd
+			m.filter, cmd = m.filter.Update(msg)
+			return m, cmd
+		}
+		return m, nil
+	}
+
+	jobs := m.visibleJobs()
+	selected := m.selectedIndex(jobs)
+	m.notice = ""
+
+	switch key {
+	case "q":
+		m.quitting = true
+		return m, tea.Quit
+	case "esc":
+		// Back out of the detail pane and filters before quitting
+		switch {
+		case m.showDetail:
+			m.showDetail = false
+		case m.filter.Value() != "" || m.statusFilter != 0:
+			m.filter.SetValue("")
+			m.statusFilter = 0
+		default:
+			m.quitting = true
+			return m, tea.Quit
+		}
+	case "r":
+		// Refresh - fetch new jobs
+		if !m.fetching {
+			m.fetching = true
+			return m, fetchJobs(m.client)
+		}
+	case "up", "k":
+		if selected > 0 {
+			m.selectedID = jobs[selected-1].ID
+		}
+	case "down", "j":
+		if selected >= 0 && selected < len(jobs)-1 {
+			m.selectedID = jobs[selected+1].ID
+		}
+	case "enter":
+		m.showDetail = !m.showDetail && selected >= 0
+	case "/":
+		return m, m.filter.Focus()
+	case "f":
+		m.statusFilter = (m.statusFilter + 1) % len(jobStatusFilters)
+	case "s":
+		m.sortBy = (m.sortBy + 1) % len(jobSorts)
+	case "c":
+		if selected < 0 {
+			return m, nil
+		}
+		job := jobs[selected]
+		if job.Status != "running" && job.Status != "queued" {
+			m.notice = "Only queued or running jobs can be cancelled"
+			return m, nil
+		}
+		m.confirmCancel = job.ID
+	}
+
+	return m, nil
+}
+
+// visibleJobs returns the jobs that pass the filters, in display order
+func (m liveJobsModel) visibleJobs() []Job {
+	status := jobStatusFilters[m.statusFilter]
+	query := m.filter.Value()
+
+	var jobs []Job
+	for _, job := range m.jobs {
+		if status != "" && job.Status != status {
+			continue
+		}
+		if !matchesJobQuery(job, query) {
+			continue
+		}
+		jobs = append(jobs, job)
+	}
+	sortJobs(jobs, jobSorts[m.sortBy])
+	return jobs
+}
+
+// selectedIndex finds the selected job, falling back to the first one. It
+// returns -1 when there are no jobs.
+func (m liveJobsModel) selectedIndex(jobs []Job) int {
+	if len(jobs) == 0 {
+		return -1
+	}
+	if i := slices.IndexFunc(jobs, func(j Job) bool { return j.ID == m.selectedID }); i >= 0 {
+		return i
+	}
+	return 0
+}
+
+// matchesJobQuery matches every word of the query against the job.
+// "model:", "node:" and "status:" words match that field only; other words
+// match the ID, model or node.
+func matchesJobQuery(job Job, query string) bool {
+	contains := func(s, sub string) bool {
+		return strings.Contains(strings.ToLower(s), sub)
+	}
+	for _, word := range strings.Fields(strings.ToLower(query)) {
+		field, value, scoped := strings.Cut(word, ":")
+		var ok bool
+		switch {
+		case scoped && field == "model":
+			ok = contains(job.Model, value)
+		case scoped && field == "node":
+			ok = contains(job.NodeID, value)
+		case scoped && field == "status":
+			ok = contains(job.Status, value)
+		default:
+			ok = contains(job.ID, word) || contains(job.Model, word) || contains(job.NodeID, word)
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func sortJobs(jobs []Job, by string) {
+	switch by {
+	case "newest":
+		slices.SortStableFunc(jobs, func(a, b Job) int { return b.StartTime.Compare(a.StartTime) })
+	case "longest":
+		slices.SortStableFunc(jobs, func(a, b Job) int { return cmp.Compare(b.Duration, a.Duration) })
+	case "model":
+		slices.SortStableFunc(jobs, func(a, b Job) int { return strings.Compare(a.Model, b.Model) })
+	}
+}
+
+// filterStatus summarizes active filters and the sort order
+func (m liveJobsModel) filterStatus(shown int) string {
+	status := jobStatusFilters[m.statusFilter]
+	if status == "" {
+		status = "all"
+	}
+	line := fmt.Sprintf("Showing %d of %d  |  Status: %s  |  Sort: %s", shown, len(m.jobs), status, jobSorts[m.sortBy])
+	if q := m.filter.Value(); q != "" && !m.filter.Focused() {
+		line += fmt.Sprintf("  |  Filter: %q", q)
+	}
+	return line
+}
+
+// renderJobDetail renders the detail pane for the selected job
+func renderJobDetail(job Job) string {
+	t := currentTheme
+	label := lipgloss.NewStyle().Foreground(t.Label)
+	value := lipgloss.NewStyle().Foreground(t.Value)
+	muted := lipgloss.NewStyle().Foreground(t.Border)
+
+	row := func(name, text string) string {
+		// Join side by side so wrapped values stay indented
+		return lipgloss.JoinHorizontal(lipgloss.Top, label.Render(fmt.Sprintf("%-10s", name+":")), text) + "\n"
+	}
+
+	var out strings.Builder
+	out.WriteString(lipgloss.NewStyle().Bold(true).Foreground(t.Primary).Render("JOB DETAILS"))
+	out.WriteString("\n")
+	out.WriteString(row("ID", value.Render(job.ID)))
+	model := job.Model
+	if job.ModelType != "" {
+		model += muted.Render(" (" + job.ModelType + ")")
+	}
+	out.WriteString(row("Model", value.Render(model)))
+	out.WriteString(row("Node", value.Render(orDash(job.NodeID))))
+	out.WriteString(row("Status", value.Render(strings.ToUpper(job.Status))))
+	if job.Cost > 0 {
+		out.WriteString(row("Cost", value.Render(fmt.Sprintf("%.2f credits", job.Cost))))
+	} else {
+		out.WriteString(row("Cost", muted.Render("-")))
+	}
+	out.WriteString(row("Timeline", jobTimeline(job)))
+
+	prompt := muted.Render("not available")
+	if job.Prompt != "" {
+		prompt = value.Width(60).MaxHeight(3).Render(strings.Join(strings.Fields(job.Prompt), " "))
+	}
+	out.WriteString(row("Prompt", prompt))
+
+	if job.Error != "" {
+		out.WriteString(row("Error", lipgloss.NewStyle().Foreground(t.Error).Width(60).Render(job.Error)))
+	}
+
+	return lipgloss.NewStyle().
+		Border(Icon.Border).
+		BorderForeground(t.Primary).
+		Padding(0, 1).
+		Width(74).
+		Render(strings.TrimRight(out.String(), "\n"))
+}
+
+// jobTimeline lists when the job was queued, started and finished
+func jobTimeline(job Job) string {
+	var steps []string
+	if !job.QueuedAt.IsZero() {
+		steps = append(steps, "queued "+job.QueuedAt.Local().Format("15:04:05"))
+	}
+	if !job.StartTime.IsZero() {
+		step := "started " + job.StartTime.Local().Format("15:04:05")
+		if !job.QueuedAt.IsZero() {
+			step += fmt.Sprintf(" (waited %s)", job.StartTime.Sub(job.QueuedAt).Round(100*time.Millisecond))
+		}
+		steps = append(steps, step)
+	}
+	if !job.FinishedAt.IsZero() {
+		steps = append(steps, fmt.Sprintf("%s %s", job.Status, job.FinishedAt.Local().Format("15:04:05")))
+	}
+	if len(steps) == 0 {
+		return "-"
+	}
+	return strings.Join(steps, " "+Icon.Arrow+" ")
+}
+
+func cancelJob(c *client.ThroneClient, id string) tea.Cmd {
+	return func() tea.Msg {
+		if c == nil {
+			return jobCancelMsg{id: id, err: fmt.Errorf("not connected to throne")}
+		}
+		return jobCancelMsg{id: id, err: c.CancelJob(id)}
+	}
+}
+
+// ShortID abbreviates a job ID for lists and one-line output
+func ShortID(id string) string {
+	if len(id) > 8 {
+		return id[:8]
+	}
+	return id
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
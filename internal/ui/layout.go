@@ -0,0 +1,101 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/sovereynai/reign/internal/client"
+	"github.com/sovereynai/reign/internal/config"
+)
+
+// Section IDs used in layout settings, in default display order
+var (
+	DeveloperSections = []string{"models", "credits", "performance", "insights", "network"}
+	OperatorSections  = []string{"earnings", "revenue", "workload", "hardware", "models", "network", "alerts"}
+)
+
+// modelColumn is one selectable column of the developer models table
+type modelColumn struct {
+	header string
+	width  int
+	value  func(m client.ModelUsage) string
+}
+
+var modelUsageColumns = map[string]modelColumn{
+	"name":     {"Model", 15, func(m client.ModelUsage) string { return m.Name }},
+	"today":    {"Today", 10, func(m client.ModelUsage) string { return fmt.Sprintf("%d req", m.RequestsToday) }},
+	"week_avg": {"7d Avg", 12, func(m client.ModelUsage) string { return fmt.Sprintf("%.1f/day", m.WeekAvg) }},
+	"latency":  {"Latency", 10, func(m client.ModelUsage) string { return fmt.Sprintf("%dms", m.AvgLatencyMs) }},
+	"credits":  {"Credits", 10, func(m client.ModelUsage) string { return fmt.Sprintf("%.1fc", m.CreditsSpent) }},
+}
+
+// modelServedColumns render the metrics of a served model; "status" and
+// "name" are drawn before them as a colored icon and a padded name
+var modelServedColumns = map[string]func(m client.ModelServed) string{
+	"requests": func(m client.ModelServed) string { return fmt.Sprintf("%d reqs", m.Requests) },
+	"latency":  func(m client.ModelServed) string { return fmt.Sprintf("Avg: %dms ", m.AvgLatencyMs) },
+	"revenue":  func(m client.ModelServed) string { return fmt.Sprintf("Rev: %.1fc", m.Revenue) },
+}
+
+// Default model table columns, in display order
+var (
+	DeveloperModelColumns = []string{"name", "today", "week_avg", "latency", "credits"}
+	OperatorModelColumns  = []string{"status", "name", "requests", "latency", "revenue"}
+)
+
+var currentLayout config.Layout
+
+// UseLayout applies dashboard layout settings after checking that every
+// section and column ID is known
+func UseLayout(l config.Layout) error {
+	if err := checkLayout("developer", l.Developer, DeveloperSections, DeveloperModelColumns); err != nil {
+		return err
+	}
+	if err := checkLayout("operator", l.Operator, OperatorSections, OperatorModelColumns); err != nil {
+		return err
+	}
+	currentLayout = l
+	return nil
+}
+
+func checkLayout(name string, l config.DashboardLayout, sections, columns []string) error {
+	for _, id := range slices.Concat(l.Sections, l.Hide) {
+		if !slices.Contains(sections, id) {
+			return fmt.Errorf("unknown %s dashboard section %q (valid: %s)", name, id, strings.Join(sections, ", "))
+		}
+	}
+	for _, id := range l.ModelColumns {
+		if !slices.Contains(columns, id) {
+			return fmt.Errorf("unknown %s model column %q (valid: %s)", name, id, strings.Join(columns, ", "))
+		}
+	}
+	return nil
+}
+
+// arrange orders and filters sections and replaces quick actions as
+// configured by the layout
+func (d *dashboard) arrange(l config.DashboardLayout) {
+	if len(l.Sections) > 0 {
+		var ordered []section
+		for _, id := range l.Sections {
+			if i := slices.IndexFunc(d.Sections, func(s section) bool { return s.ID == id }); i >= 0 {
+				ordered = append(ordered, d.Sections[i])
+			}
+		}
+		d.Sections = ordered
+	}
+	d.Sections = slices.DeleteFunc(d.Sections, func(s section) bool { return slices.Contains(l.Hide, s.ID) })
+
+	if l.QuickActions != nil {
+		d.Actions = l.QuickActions
+	}
+}
+
+// columnsOrDefault returns the configured columns, or the defaults when unset
+func columnsOrDefault(configured, defaults []string) []string {
+	if len(configured) > 0 {
+		return configured
+	}
+	return defaults
+}
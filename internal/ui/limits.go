@@ -0,0 +1,134 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sovereynai/reign/internal/client"
+)
+
+// RenderLimits renders the account and per-model rate limits with their
+// usage, headroom and reset times
+func RenderLimits(limits *client.Limits, now time.Time) string {
+	d := dashboard{
+		Title:    WithIcon(Icon.Hourglass, "REIGN - Rate Limits & Quotas"),
+		Subtitle: now.Format("15:04:05"),
+	}
+
+	account := mutedStyle.Render("  No account-wide limits") + "\n"
+	if len(limits.Account) > 0 {
+		account = renderLimitRows(limits.Account, "  ", now)
+	}
+	d.Sections = append(d.Sections, section{
+		ID:    "account",
+		Title: WithIcon(Icon.Credits, "ACCOUNT"),
+		Body:  account,
+	})
+
+	if len(limits.Models) > 0 {
+		var models strings.Builder
+		for _, m := range limits.Models {
+			models.WriteString("  " + valueStyle.Render(m.Model) + "\n")
+			models.WriteString(renderLimitRows(m.Limits, "    ", now))
+		}
+		d.Sections = append(d.Sections, section{
+			ID:    "models",
+			Title: WithIcon(Icon.Robot, "PER MODEL"),
+			Body:  models.String(),
+		})
+	}
+
+	if near := nearLimits(limits); len(near) > 0 {
+		d.Sections = append(d.Sections, section{
+			ID:    "warnings",
+			Title: WithIcon(Icon.Alert, "NEAR LIMIT"),
+			Body:  renderInsights(near),
+		})
+	}
+
+	return d.render()
+}
+
+func renderLimitRows(limits []client.Limit, indent string, now time.Time) string {
+	var out strings.Builder
+	for _, l := range limits {
+		reset := ""
+		if !l.ResetsAt.IsZero() {
+			reset = mutedStyle.Render("resets in " + formatReset(l.ResetsAt.Sub(now)))
+		}
+		// Pad before styling so the columns line up
+		out.WriteString(fmt.Sprintf("%s%s %s %s %s %s\n",
+			indent,
+			labelStyle.Render(fmt.Sprintf("%-20s", limitLabel(l))),
+			renderProgressBar(l.UsedFraction(), 10),
+			valueStyle.Render(fmt.Sprintf("%-15s", formatAmount(l.Used)+" / "+formatAmount(l.Limit))),
+			headroomStyle(l).Render(fmt.Sprintf("%-12s", formatAmount(l.Remaining())+" left")),
+			reset,
+		))
+	}
+	return out.String()
+}
+
+// nearLimits describes limits that are 80% or more used
+func nearLimits(limits *client.Limits) []string {
+	var out []string
+	describe := func(scope string, l client.Limit) {
+		if l.Limit > 0 && l.UsedFraction() >= 0.8 {
+			out = append(out, fmt.Sprintf("%s: %.0f%% of %s used", scope, l.UsedFraction()*100, strings.ToLower(limitLabel(l))))
+		}
+	}
+	for _, l := range limits.Account {
+		describe("Account", l)
+	}
+	for _, m := range limits.Models {
+		for _, l := range m.Limits {
+			describe(m.Model, l)
+		}
+	}
+	return out
+}
+
+func limitLabel(l client.Limit) string {
+	name := l.Name
+	if name != "" {
+		name = strings.ToUpper(name[:1]) + name[1:]
+	}
+	if l.Window == "" {
+		return name
+	}
+	return name + " / " + l.Window
+}
+
+func headroomStyle(l client.Limit) lipgloss.Style {
+	switch f := l.UsedFraction(); {
+	case f >= 0.8:
+		return errorStyle
+	case f >= 0.5:
+		return warningStyle
+	}
+	return successStyle
+}
+
+func formatAmount(v float64) string {
+	if v == float64(int64(v)) {
+		return fmt.Sprintf("%d", int64(v))
+	}
+	return fmt.Sprintf("%.2f", v)
+}
+
+// formatReset shows a time until reset at a useful precision
+func formatReset(d time.Duration) string {
+	switch {
+	case d <= 0:
+		return "now"
+	case d < time.Minute:
+		return d.Round(time.Second).String()
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh %dm", int(d.Hours()), int(d.Minutes())%60)
+	}
+	return fmt.Sprintf("%dd", int(d.Hours()/24))
+}
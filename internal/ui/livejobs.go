@@ -7,6 +7,7 @@ import (
 
 	"github.com/charmbracelet/bubbles/progress"
 	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/sovereynai/reign/internal/client"
@@ -23,76 +24,162 @@ type Job struct {
 	StartTime time.Time
 	Duration  time.Duration
 	NodeID    string
+
+	TokensGenerated int
+	MaxTokens       int
+	TokensPerSec    float64       // Reported by throne, or measured at fetch time
+	BatchDone       int           // ONNX items processed
+	BatchTotal      int           // ONNX items in the batch
+	Estimated       time.Duration // Expected total run time, zero when unknown
+
+	Prompt     string  // Start of the prompt
+	Cost       float64 // Credits charged
+	Error      string
+	QueuedAt   time.Time
+	FinishedAt time.Time
+}
+
+// fraction returns how far along the job is, preferring token and batch
+// counts over throne's coarse progress value. ok is false when unknown.
+func (j Job) fraction() (f float64, ok bool) {
+	switch {
+	case j.MaxTokens > 0:
+		f = float64(j.TokensGenerated) / float64(j.MaxTokens)
+	case j.BatchTotal > 0:
+		f = float64(j.BatchDone) / float64(j.BatchTotal)
+	case j.Progress > 0:
+		f = j.Progress
+	default:
+		return 0, false
+	}
+	return min(f, 1), true
+}
+
+// LiveJobsOptions controls how the live jobs viewer refreshes
+type LiveJobsOptions struct {
+	Watch   bool          // Poll for updates instead of refreshing only on demand
+	Refresh time.Duration // Poll interval in watch mode
 }
 
 type liveJobsModel struct {
+	opts       LiveJobsOptions
+	client     *client.ThroneClient // Nil until throne has been discovered
 	jobs       []Job
 	spinner    spinner.Model
 	progress   progress.Model
 	quitting   bool
-	lastUpdate time.Time
+	fetching   bool
+	fetched    bool      // At least one fetch has finished
+	lastUpdate time.Time // Time of the last successful fetch
+	lastErr    error     // Error of the last fetch, if it failed
+
+	selectedID    string // Job under the cursor
+	showDetail    bool
+	statusFilter  int // Index into jobStatusFilters
+	sortBy        int // Index into jobSorts
+	filter        textinput.Model
+	confirmCancel string // Job awaiting cancel confirmation
+	notice        string // Result of the last action
 }
 
 type jobUpdateMsg struct {
-	jobs []Job
+	client *client.ThroneClient
+	jobs   []Job
+	err    error
 }
 
 type jobsTickMsg time.Time
 
+type jobsPollMsg time.Time
+
 func tickEvery(interval time.Duration) tea.Cmd {
 	return tea.Tick(interval, func(t time.Time) tea.Msg {
 		return jobsTickMsg(t)
 	})
 }
 
-func InitialJobsModel() liveJobsModel {
+func pollEvery(interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(t time.Time) tea.Msg {
+		return jobsPollMsg(t)
+	})
+}
+
+func InitialJobsModel(opts LiveJobsOptions) liveJobsModel {
+	if opts.Refresh <= 0 {
+		opts.Refresh = time.Second
+	}
+
 	s := spinner.New()
-	s.Spinner = spinner.Dot
-	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
+	s.Spinner = Icon.Spinner
+	s.Style = lipgloss.NewStyle().Foreground(currentTheme.Primary)
 
-	p := progress.New(progress.WithDefaultGradient())
+	p := progress.New(progress.WithDefaultGradient(), progress.WithFillCharacters(Icon.BarFull, Icon.BarEmpty))
 
 	return liveJobsModel{
-		jobs:       []Job{},
-		spinner:    s,
-		progress:   p,
-		lastUpdate: time.Now(),
+		opts:     opts,
+		jobs:     []Job{},
+		spinner:  s,
+		progress: p,
+		fetching: true,
+		filter:   newJobFilterInput(),
 	}
 }
 
 func (m liveJobsModel) Init() tea.Cmd {
-	return tea.Batch(
+	cmds := []tea.Cmd{
 		m.spinner.Tick,
-		tickEvery(500*time.Millisecond),
-	)
+		tickEvery(500 * time.Millisecond),
+		fetchJobs(m.client),
+	}
+	if m.opts.Watch {
+		cmds = append(cmds, pollEvery(m.opts.Refresh))
+	}
+	return tea.Batch(cmds...)
 }
 
 func (m liveJobsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "q", "esc", "ctrl+c":
-			m.quitting = true
-			return m, tea.Quit
-		case "r":
-			// Refresh - fetch new jobs
-			return m, fetchJobs
+		return m.handleKey(msg)
+
+	case jobCancelMsg:
+		if msg.err != nil {
+			m.notice = fmt.Sprintf("Could not cancel job %s: %v", ShortID(msg.id), msg.err)
+			return m, nil
+		}
+		m.notice = "Cancelled job " + ShortID(msg.id)
+		if !m.fetching {
+			m.fetching = true
+			return m, fetchJobs(m.client)
 		}
 
 	case jobUpdateMsg:
+		m.fetching = false
+		m.fetched = true
+		m.lastErr = msg.err
+		if msg.err != nil {
+			// Keep showing the last known jobs, marked as stale, and
+			// rediscover throne on the next fetch in case it moved
+			m.client = nil
+			return m, nil
+		}
+		m.client = msg.client
 		m.jobs = msg.jobs
 		m.lastUpdate = time.Now()
 		return m, nil
 
+	case jobsPollMsg:
+		if m.fetching {
+			return m, pollEvery(m.opts.Refresh)
+		}
+		m.fetching = true
+		return m, tea.Batch(fetchJobs(m.client), pollEvery(m.opts.Refresh))
+
 	case jobsTickMsg:
-		// Update job durations and progress
+		// Update durations of running jobs; progress only changes on fetch
 		for i := range m.jobs {
-			if m.jobs[i].Status == "running" {
+			if m.jobs[i].Status == "running" && !m.jobs[i].StartTime.IsZero() {
 				m.jobs[i].Duration = time.Since(m.jobs[i].StartTime)
-				// Simulate progress for running jobs
-				if m.jobs[i].Progress < 0.95 {
-					m.jobs[i].Progress += 0.05
-				}
 			}
 		}
 		return m, tickEvery(500 * time.Millisecond)
@@ -106,47 +193,80 @@ func (m liveJobsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// connectionStatus describes the throne connection and how fresh the jobs are
+func (m liveJobsModel) connectionStatus() string {
+	t := currentTheme
+	muted := lipgloss.NewStyle().Foreground(t.Border)
+
+	if !m.fetched {
+		return lipgloss.NewStyle().Foreground(t.Warning).Render(Icon.Hourglass+" Connecting to throne...") + "\n"
+	}
+
+	if m.lastErr != nil {
+		status := lipgloss.NewStyle().Foreground(t.Error).Bold(true).Render(WithIcon(Icon.Cross, "Disconnected"))
+		stale := "no jobs received yet"
+		if !m.lastUpdate.IsZero() {
+			stale = fmt.Sprintf("showing jobs from %s (%s old)",
+				m.lastUpdate.Format("15:04:05"), time.Since(m.lastUpdate).Round(time.Second))
+		}
+		return fmt.Sprintf("%s  %s\n%s\n", status, muted.Render(stale),
+			lipgloss.NewStyle().Foreground(t.Error).Width(74).Render(m.lastErr.Error()))
+	}
+
+	status := lipgloss.NewStyle().Foreground(t.Success).Bold(true).Render(WithIcon(Icon.Check, "Connected"))
+	mode := "manual refresh"
+	if m.opts.Watch {
+		mode = "every " + m.opts.Refresh.String()
+	}
+	return fmt.Sprintf("%s  %s\n", status, muted.Render(fmt.Sprintf("%s | updated %s (%s)",
+		m.client.BaseURL, m.lastUpdate.Format("15:04:05"), mode)))
+}
+
 func (m liveJobsModel) View() string {
 	if m.quitting {
 		return ""
 	}
 
+	t := currentTheme
+
 	titleStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("205")).
-		Background(lipgloss.Color("235")).
+		Foreground(t.Primary).
+		Background(t.HeaderBg).
 		Padding(0, 2)
 
 	headerStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("86")).
+		Foreground(t.Label).
 		Bold(true)
 
 	labelStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("240"))
+		Foreground(t.Border)
 
 	runningStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("220"))
+		Foreground(t.Warning)
 
 	completedStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("46"))
+		Foreground(t.Success)
 
 	failedStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("196"))
+		Foreground(t.Error)
 
 	queuedStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("240"))
+		Foreground(t.Border)
 
 	boxStyle := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("86")).
+		Border(Icon.Border).
+		BorderForeground(t.Label).
 		Padding(1, 2).
 		Width(80)
 
 	var content strings.Builder
 
 	// Header
-	content.WriteString(titleStyle.Render("🔄 LIVE INFERENCE JOBS"))
+	content.WriteString(titleStyle.Render(WithIcon(Icon.Refresh, "LIVE INFERENCE JOBS")))
 	content.WriteString("\n\n")
+	content.WriteString(m.connectionStatus())
+	content.WriteString("\n")
 
 	// Stats
 	running := 0
@@ -166,32 +286,57 @@ func (m liveJobsModel) View() string {
 		}
 	}
 
-	content.WriteString(headerStyle.Render("📊 Status: "))
+	content.WriteString(headerStyle.Render(WithIcon(Icon.Chart, "Status: ")))
 	content.WriteString(fmt.Sprintf("%s %d  %s %d  %s %d  %s %d\n\n",
-		runningStyle.Render("⚡ Running:"), running,
-		queuedStyle.Render("⏳ Queued:"), queued,
-		completedStyle.Render("✓ Completed:"), completed,
-		failedStyle.Render("✗ Failed:"), failed,
+		runningStyle.Render(WithIcon(Icon.Speed, "Running:")), running,
+		queuedStyle.Render(WithIcon(Icon.Hourglass, "Queued:")), queued,
+		completedStyle.Render(WithIcon(Icon.Check, "Completed:")), completed,
+		failedStyle.Render(WithIcon(Icon.Cross, "Failed:")), failed,
 	))
 
 	// Jobs list
+	jobs := m.visibleJobs()
+	selected := m.selectedIndex(jobs)
+	if len(m.jobs) > 0 {
+		content.WriteString(labelStyle.Render(m.filterStatus(len(jobs))))
+		content.WriteString("\n")
+	}
+	if m.filter.Focused() {
+		content.WriteString(m.filter.View())
+		content.WriteString("\n")
+	}
 	if len(m.jobs) == 0 {
-		content.WriteString(labelStyle.Render("  No active jobs. Waiting for inference requests...\n"))
-		content.WriteString(labelStyle.Render(fmt.Sprintf("  Last checked: %s\n", m.lastUpdate.Format("15:04:05"))))
+		if m.lastErr == nil && m.fetched {
+			content.WriteString(labelStyle.Render("  No active jobs. Waiting for inference requests...\n"))
+		}
 	} else {
-		for _, job := range m.jobs {
-			content.WriteString(renderJob(job, m.progress, m.spinner))
+		for i, job := range jobs {
+			content.WriteString(renderJob(job, m.progress, m.spinner, i == selected))
 			content.WriteString("\n")
 		}
 	}
 
+	if m.showDetail && selected >= 0 {
+		content.WriteString(renderJobDetail(jobs[selected]))
+		content.WriteString("\n")
+	}
+
 	content.WriteString("\n")
-	content.WriteString(labelStyle.Render("Press [r] to refresh  [q] to quit"))
+	if m.confirmCancel != "" {
+		content.WriteString(runningStyle.Bold(true).Render(fmt.Sprintf("Cancel job %s? [y/N]", ShortID(m.confirmCancel))))
+		content.WriteString("\n")
+	} else if m.notice != "" {
+		content.WriteString(headerStyle.Render(m.notice))
+		content.WriteString("\n")
+	}
+	content.WriteString(labelStyle.Render("[up/down] select  [enter] details  [/] filter  [f] status  [s] sort\n[c] cancel  [r] refresh  [q] quit"))
 
 	return boxStyle.Render(content.String())
 }
 
-func renderJob(job Job, prog progress.Model, spin spinner.Model) string {
+func renderJob(job Job, prog progress.Model, spin spinner.Model, selected bool) string {
+	t := currentTheme
+
 	var statusIcon, statusText string
 	var statusStyle lipgloss.Style
 
@@ -199,24 +344,24 @@ func renderJob(job Job, prog progress.Model, spin spinner.Model) string {
 	case "running":
 		statusIcon = spin.View()
 		statusText = "RUNNING"
-		statusStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("220"))
+		statusStyle = lipgloss.NewStyle().Foreground(t.Warning)
 	case "queued":
-		statusIcon = "⏳"
+		statusIcon = Icon.Hourglass
 		statusText = "QUEUED"
-		statusStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+		statusStyle = lipgloss.NewStyle().Foreground(t.Border)
 	case "completed":
-		statusIcon = "✓"
+		statusIcon = Icon.Check
 		statusText = "COMPLETED"
-		statusStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("46"))
+		statusStyle = lipgloss.NewStyle().Foreground(t.Success)
 	case "failed":
-		statusIcon = "✗"
+		statusIcon = Icon.Cross
 		statusText = "FAILED"
-		statusStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+		statusStyle = lipgloss.NewStyle().Foreground(t.Error)
 	}
 
-	modelIcon := "🤖"
+	modelIcon := Icon.Robot
 	if job.ModelType == "onnx" {
-		modelIcon = "🔬"
+		modelIcon = Icon.Microscope
 	}
 
 	// Format duration
@@ -226,93 +371,160 @@ func renderJob(job Job, prog progress.Model, spin spinner.Model) string {
 		durationStr = "-"
 	}
 
+	borderColor := t.Border
+	if selected {
+		borderColor = t.Primary
+	}
+
 	jobBox := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("240")).
+		Border(Icon.Border).
+		BorderForeground(borderColor).
 		Padding(0, 1).
-		Width(76)
+		Width(74)
 
 	var content strings.Builder
-	content.WriteString(fmt.Sprintf("%s %s  %s %s  ",
-		statusIcon,
-		statusStyle.Bold(true).Render(statusText),
-		modelIcon,
-		lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("255")).Render(job.Model),
+	if selected {
+		content.WriteString(lipgloss.NewStyle().Foreground(t.Primary).Render(Icon.Arrow) + " ")
+	}
+	content.WriteString(fmt.Sprintf("%s  %s  ",
+		WithIcon(statusIcon, statusStyle.Bold(true).Render(statusText)),
+		WithIcon(modelIcon, lipgloss.NewStyle().Bold(true).Foreground(t.Value).Render(job.Model)),
 	))
-	content.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(fmt.Sprintf("(%s)", durationStr)))
+	content.WriteString(lipgloss.NewStyle().Foreground(t.Border).Render(fmt.Sprintf("(%s)", durationStr)))
 	content.WriteString("\n")
 
-	content.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(fmt.Sprintf("Job: %s  Node: %s", job.ID[:8], job.NodeID)))
+	content.WriteString(lipgloss.NewStyle().Foreground(t.Border).Render(fmt.Sprintf("Job: %s  Node: %s", ShortID(job.ID), job.NodeID)))
 	content.WriteString("\n")
 
 	// Progress bar for running jobs
 	if job.Status == "running" {
-		prog.Width = 70
-		content.WriteString(prog.ViewAs(job.Progress))
-		content.WriteString(fmt.Sprintf(" %.0f%%", job.Progress*100))
+		if f, ok := job.fraction(); ok {
+			prog.Width = 60
+			content.WriteString(prog.ViewAs(f))
+		} else {
+			content.WriteString(lipgloss.NewStyle().Foreground(t.Border).Render("Progress not reported"))
+		}
+	}
+
+	if details := jobProgressDetails(job); details != "" {
+		if job.Status == "running" {
+			content.WriteString("\n")
+		}
+		content.WriteString(lipgloss.NewStyle().Foreground(t.Label).Render(details))
 	}
 
-	return jobBox.Render(content.String())
+	return jobBox.Render(strings.TrimRight(content.String(), "\n"))
 }
 
-// fetchJobs fetches real jobs from throne API
-func fetchJobs() tea.Msg {
-	// Get throne daemon URL
-	cfg, err := config.Load()
-	if err != nil {
-		// Return empty jobs on error
-		return jobUpdateMsg{jobs: []Job{}}
+// jobProgressDetails describes token or batch counts, throughput and timing
+func jobProgressDetails(job Job) string {
+	var parts []string
+	if job.MaxTokens > 0 {
+		parts = append(parts, fmt.Sprintf("Tokens: %d/%d", job.TokensGenerated, job.MaxTokens))
+	} else if job.TokensGenerated > 0 {
+		parts = append(parts, fmt.Sprintf("Tokens: %d", job.TokensGenerated))
+	}
+	if job.BatchTotal > 0 {
+		parts = append(parts, fmt.Sprintf("Batch: %d/%d", job.BatchDone, job.BatchTotal))
+	}
+	if job.TokensPerSec > 0 {
+		parts = append(parts, fmt.Sprintf("%.1f tok/s", job.TokensPerSec))
 	}
 
-	// Create throne client
-	throneClient := client.NewThroneClient(cfg.ThroneURL)
+	if job.Status == "running" && job.Estimated > 0 {
+		elapsed := job.Duration.Round(100 * time.Millisecond)
+		parts = append(parts, fmt.Sprintf("Elapsed: %s / ~%s", elapsed, job.Estimated.Round(time.Second)))
+		if remaining := job.Estimated - job.Duration; remaining > 0 {
+			parts = append(parts, fmt.Sprintf("ETA: %s", remaining.Round(time.Second)))
+		} else {
+			parts = append(parts, "ETA: any moment")
+		}
+	}
+
+	return strings.Join(parts, "  "+Icon.Bullet+" ")
+}
 
-	// Fetch live jobs
-	response, err := throneClient.GetLiveJobs()
-	if err != nil {
-		// Return empty jobs on error
-		return jobUpdateMsg{jobs: []Job{}}
+// fetchJobs fetches real jobs from throne API, discovering throne first when
+// no client is known yet
+func fetchJobs(c *client.ThroneClient) tea.Cmd {
+	return func() tea.Msg {
+		if c == nil {
+			cfg, err := config.Load()
+			if err != nil {
+				return jobUpdateMsg{err: err}
+			}
+			c = client.NewThroneClient(cfg.ThroneURL)
+		}
+
+		response, err := c.GetLiveJobs()
+		if err != nil {
+			return jobUpdateMsg{err: err}
+		}
+		return jobUpdateMsg{client: c, jobs: convertJobs(response)}
 	}
+}
 
-	// Convert API response to UI jobs
+func convertJobs(response *client.LiveJobsResponse) []Job {
+	// Convert API response to UI jobs: active jobs first, then recent ones
 	var jobs []Job
-
-	// Add active jobs
 	for _, apiJob := range response.Active {
-		startTime, _ := time.Parse(time.RFC3339Nano, apiJob.StartTime)
-		jobs = append(jobs, Job{
-			ID:        apiJob.ID,
-			Model:     apiJob.Model,
-			ModelType: apiJob.ModelType,
-			Status:    apiJob.Status,
-			Progress:  apiJob.Progress,
-			StartTime: startTime,
-			Duration:  time.Duration(apiJob.Duration) * time.Millisecond,
-			NodeID:    apiJob.NodeID,
-		})
-	}
-
-	// Add recent completed jobs
+		jobs = append(jobs, jobFromAPI(apiJob))
+	}
 	for _, apiJob := range response.Recent {
-		startTime, _ := time.Parse(time.RFC3339Nano, apiJob.StartTime)
-		jobs = append(jobs, Job{
-			ID:        apiJob.ID,
-			Model:     apiJob.Model,
-			ModelType: apiJob.ModelType,
-			Status:    apiJob.Status,
-			Progress:  apiJob.Progress,
-			StartTime: startTime,
-			Duration:  time.Duration(apiJob.Duration) * time.Millisecond,
-			NodeID:    apiJob.NodeID,
-		})
-	}
-
-	return jobUpdateMsg{jobs: jobs}
+		jobs = append(jobs, jobFromAPI(apiJob))
+	}
+	return jobs
+}
+
+func jobFromAPI(apiJob client.LiveJob) Job {
+	startTime, _ := time.Parse(time.RFC3339Nano, apiJob.StartTime)
+	job := Job{
+		ID:              apiJob.ID,
+		Model:           apiJob.Model,
+		ModelType:       apiJob.ModelType,
+		Status:          apiJob.Status,
+		Progress:        apiJob.Progress,
+		StartTime:       startTime,
+		Duration:        time.Duration(apiJob.Duration) * time.Millisecond,
+		NodeID:          apiJob.NodeID,
+		TokensGenerated: apiJob.TokensGenerated,
+		MaxTokens:       apiJob.MaxTokens,
+		TokensPerSec:    apiJob.TokensPerSec,
+		BatchDone:       apiJob.BatchDone,
+		BatchTotal:      apiJob.BatchTotal,
+		Prompt:          apiJob.PromptPreview,
+		Cost:            apiJob.Cost,
+		Error:           apiJob.Error,
+	}
+	job.QueuedAt, _ = time.Parse(time.RFC3339Nano, apiJob.QueuedAt)
+	job.FinishedAt, _ = time.Parse(time.RFC3339Nano, apiJob.FinishedAt)
+	if job.Status == "running" && !startTime.IsZero() {
+		job.Duration = time.Since(startTime)
+	}
+	elapsed := job.Duration
+
+	if job.TokensPerSec == 0 && job.TokensGenerated > 0 && elapsed > 0 {
+		job.TokensPerSec = float64(job.TokensGenerated) / elapsed.Seconds()
+	}
+
+	// Estimate total run time from the server's ETA, the token rate, or
+	// the elapsed time per unit of progress, in that order
+	switch f, ok := job.fraction(); {
+	case apiJob.EtaMs > 0:
+		job.Estimated = elapsed + time.Duration(apiJob.EtaMs)*time.Millisecond
+	case job.MaxTokens > 0 && job.TokensPerSec > 0:
+		remaining := float64(job.MaxTokens-job.TokensGenerated) / job.TokensPerSec
+		job.Estimated = elapsed + time.Duration(remaining*float64(time.Second))
+	case ok && f > 0:
+		job.Estimated = time.Duration(float64(elapsed) / f)
+	}
+
+	return job
 }
 
 // ShowLiveJobs displays the live jobs monitor
-func ShowLiveJobs() error {
-	p := tea.NewProgram(InitialJobsModel())
+func ShowLiveJobs(opts LiveJobsOptions) error {
+	p := tea.NewProgram(InitialJobsModel(opts))
 	if _, err := p.Run(); err != nil {
 		return fmt.Errorf("error running live jobs viewer: %w", err)
 	}
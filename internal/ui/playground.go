@@ -0,0 +1,525 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sovereynai/reign/internal/client"
+)
+
+// maxPlaygroundModels is how many models can be compared at once
+const maxPlaygroundModels = 3
+
+// PlaygroundOptions sets the playground's initial parameters
+type PlaygroundOptions struct {
+	Models      []string
+	Temperature string // Empty for the model's default
+	MaxTokens   string
+	System      string
+
+	// BeforeRun can stop a run from being sent, e.g. when its requests
+	// together are over budget. Its error is shown in place of each model's
+	// output.
+	BeforeRun func(reqs []client.ChatRequest) error
+
+	// OnResult is called after each model finishes or is stopped, e.g. to
+	// record history. Calls come from the requests' goroutines, concurrently.
+	OnResult func(req client.ChatRequest, resp *client.ChatResponse, err error, elapsed time.Duration)
+}
+
+// Playground fields, in focus order
+const (
+	fieldPrompt = iota
+	fieldModels
+	fieldTemperature
+	fieldMaxTokens
+	fieldSystem
+	fieldCount
+)
+
+// playColumn is one model's output in a run
+type playColumn struct {
+	model      string
+	output     strings.Builder
+	running    bool
+	started    time.Time
+	firstToken time.Duration
+	elapsed    time.Duration
+	resp       *client.ChatResponse
+	err        error
+}
+
+type playgroundModel struct {
+	client *client.ThroneClient
+	opts   PlaygroundOptions
+
+	prompt textarea.Model
+	params [fieldCount]textinput.Model // Indexed by field; fieldPrompt is unused
+	focus  int
+
+	spinner spinner.Model
+	columns []*playColumn
+	run     int                // Incremented per run so stale messages are dropped
+	cancel  context.CancelFunc // Stops the current run
+	events  chan tea.Msg
+	scroll  int // Lines scrolled up from the end of the output
+	notice  string
+
+	width, height int
+}
+
+// Messages from a run's streaming goroutines
+type (
+	playDeltaMsg struct {
+		run, col int
+		text     string
+	}
+	playDoneMsg struct {
+		run, col int
+		resp     *client.ChatResponse
+		err      error
+		elapsed  time.Duration
+	}
+	playFinishedMsg struct{ run int }
+)
+
+func newPlaygroundModel(c *client.ThroneClient, opts PlaygroundOptions) playgroundModel {
+	prompt := textarea.New()
+	prompt.Placeholder = "Type a prompt, then press ctrl+r to run it"
+	prompt.ShowLineNumbers = false
+	prompt.SetHeight(5)
+	prompt.Focus()
+
+	s := spinner.New()
+	s.Spinner = Icon.Spinner
+	s.Style = lipgloss.NewStyle().Foreground(currentTheme.Primary)
+
+	m := playgroundModel{
+		client:  c,
+		opts:    opts,
+		prompt:  prompt,
+		spinner: s,
+		width:   100,
+		height:  32,
+	}
+
+	values := [fieldCount]string{
+		fieldModels:      strings.Join(opts.Models, ", "),
+		fieldTemperature: opts.Temperature,
+		fieldMaxTokens:   opts.MaxTokens,
+		fieldSystem:      opts.System,
+	}
+	placeholders := [fieldCount]string{
+		fieldModels:      "llama3.2:3b, qwen2.5:7b",
+		fieldTemperature: "model default",
+		fieldMaxTokens:   "model default",
+		fieldSystem:      "none",
+	}
+	for f := fieldModels; f < fieldCount; f++ {
+		ti := textinput.New()
+		ti.Prompt = ""
+		ti.Placeholder = placeholders[f]
+		ti.Width = paramsWidth - 18 // Less the label and cursor
+		ti.SetValue(values[f])
+		m.params[f] = ti
+	}
+	m.layout()
+	return m
+}
+
+func (m playgroundModel) Init() tea.Cmd {
+	return tea.Batch(textarea.Blink, m.spinner.Tick)
+}
+
+func (m playgroundModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.layout()
+		return m, nil
+
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+
+	case spinner.TickMsg:
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+
+	case playDeltaMsg:
+		if msg.run != m.run {
+			return m, nil // A stopped run's listener; the current run has its own
+		}
+		col := m.columns[msg.col]
+		if col.output.Len() == 0 {
+			col.firstToken = time.Since(col.started)
+		}
+		col.output.WriteString(msg.text)
+		return m, m.listen()
+
+	case playDoneMsg:
+		if msg.run != m.run {
+			return m, nil
+		}
+		col := m.columns[msg.col]
+		col.running = false
+		col.resp, col.err, col.elapsed = msg.resp, msg.err, msg.elapsed
+		if msg.resp != nil && col.output.Len() == 0 {
+			col.output.WriteString(msg.resp.Message.Content)
+		}
+		return m, m.listen()
+
+	case playFinishedMsg:
+		if msg.run == m.run {
+			m.cancel = nil
+			m.events = nil
+		}
+		return m, nil
+	}
+
+	return m.updateFocused(msg)
+}
+
+func (m playgroundModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.notice = ""
+	switch msg.String() {
+	case "ctrl+c":
+		m.stop()
+		return m, tea.Quit
+	case "ctrl+r":
+		return m.start()
+	case "esc":
+		m.stop()
+		return m, nil
+	case "tab":
+		m.setFocus((m.focus + 1) % fieldCount)
+		return m, nil
+	case "shift+tab":
+		m.setFocus((m.focus + fieldCount - 1) % fieldCount)
+		return m, nil
+	case "pgup":
+		m.scroll += m.outputHeight() / 2
+		return m, nil
+	case "pgdown":
+		m.scroll = max(0, m.scroll-m.outputHeight()/2)
+		return m, nil
+	}
+	return m.updateFocused(msg)
+}
+
+func (m playgroundModel) updateFocused(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	if m.focus == fieldPrompt {
+		m.prompt, cmd = m.prompt.Update(msg)
+	} else {
+		m.params[m.focus], cmd = m.params[m.focus].Update(msg)
+	}
+	return m, cmd
+}
+
+func (m *playgroundModel) setFocus(f int) {
+	m.prompt.Blur()
+	for i := fieldModels; i < fieldCount; i++ {
+		m.params[i].Blur()
+	}
+	m.focus = f
+	if f == fieldPrompt {
+		m.prompt.Focus()
+	} else {
+		m.params[f].Focus()
+	}
+}
+
+// start runs the prompt against every model at once
+func (m playgroundModel) start() (tea.Model, tea.Cmd) {
+	prompt := strings.TrimSpace(m.prompt.Value())
+	if prompt == "" {
+		m.notice = "Enter a prompt first"
+		return m, nil
+	}
+	models, req, err := m.request(prompt)
+	if err != nil {
+		m.notice = err.Error()
+		return m, nil
+	}
+
+	m.stop()
+	m.run++
+	m.scroll = 0
+	m.columns = nil
+	now := time.Now()
+	for _, model := range models {
+		m.columns = append(m.columns, &playColumn{model: model, running: true, started: now})
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancel = cancel
+	m.events = make(chan tea.Msg, 64)
+
+	// Sends give up once the run is stopped, as nothing reads them any more
+	run, events, c, onResult := m.run, m.events, m.client, m.opts.OnResult
+	send := func(msg tea.Msg) {
+		select {
+		case events <- msg:
+		case <-ctx.Done():
+		}
+	}
+
+	var runErr error
+	if m.opts.BeforeRun != nil {
+		var reqs []client.ChatRequest
+		for _, model := range models {
+			reqs = append(reqs, withModel(req, model))
+		}
+		runErr = m.opts.BeforeRun(reqs)
+	}
+
+	var wg sync.WaitGroup
+	for i, model := range models {
+		if runErr != nil {
+			m.columns[i].running = false
+			m.columns[i].err = runErr
+			continue
+		}
+		wg.Add(1)
+		go func(col int, req client.ChatRequest) {
+			defer wg.Done()
+			resp, err := c.StreamChat(ctx, req, func(text string) {
+				send(playDeltaMsg{run: run, col: col, text: text})
+			})
+			elapsed := time.Since(now)
+			// Recorded here rather than on the done message, which is dropped
+			// once the run is stopped, as the request may have been charged
+			if onResult != nil {
+				onResult(req, resp, err, elapsed)
+			}
+			send(playDoneMsg{run: run, col: col, resp: resp, err: err, elapsed: elapsed})
+		}(i, withModel(req, model))
+	}
+	go func() {
+		wg.Wait()
+		close(events)
+	}()
+
+	return m, m.listen()
+}
+
+// request builds the chat request from the parameter fields
+func (m playgroundModel) request(prompt string) ([]string, client.ChatRequest, error) {
+	var req client.ChatRequest
+
+	var models []string
+	for _, name := range strings.Split(m.params[fieldModels].Value(), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			models = append(models, name)
+		}
+	}
+	if len(models) == 0 {
+		return nil, req, fmt.Errorf("enter at least one model")
+	}
+	if len(models) > maxPlaygroundModels {
+		return nil, req, fmt.Errorf("compare at most %d models at a time", maxPlaygroundModels)
+	}
+
+	opts := &client.ChatOptions{}
+	if v := strings.TrimSpace(m.params[fieldTemperature].Value()); v != "" {
+		t, err := strconv.ParseFloat(v, 64)
+		if err != nil || t < 0 {
+			return nil, req, fmt.Errorf("invalid temperature %q", v)
+		}
+		opts.Temperature = &t
+	}
+	if v := strings.TrimSpace(m.params[fieldMaxTokens].Value()); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			return nil, req, fmt.Errorf("invalid max tokens %q", v)
+		}
+		opts.MaxTokens = n
+	}
+	if opts.Temperature != nil || opts.MaxTokens > 0 {
+		req.Options = opts
+	}
+
+	if system := strings.TrimSpace(m.params[fieldSystem].Value()); system != "" {
+		req.Messages = append(req.Messages, client.ChatMessage{Role: "system", Content: system})
+	}
+	req.Messages = append(req.Messages, client.ChatMessage{Role: "user", Content: prompt})
+	return models, req, nil
+}
+
+func withModel(req client.ChatRequest, model string) client.ChatRequest {
+	req.Model = model
+	req.Messages = append([]client.ChatMessage(nil), req.Messages...)
+	return req
+}
+
+// listen waits for the next message from the current run
+func (m playgroundModel) listen() tea.Cmd {
+	run, events := m.run, m.events
+	if events == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		msg, ok := <-events
+		if !ok {
+			return playFinishedMsg{run: run}
+		}
+		return msg
+	}
+}
+
+// stop cancels the current run, keeping whatever output arrived
+func (m *playgroundModel) stop() {
+	if m.cancel == nil {
+		return
+	}
+	m.cancel()
+	m.cancel = nil
+	m.events = nil
+	m.run++
+	for _, col := range m.columns {
+		if col.running {
+			col.running = false
+			col.elapsed = time.Since(col.started)
+			col.err = context.Canceled
+		}
+	}
+}
+
+// paramsWidth is the width of the parameter panel
+const paramsWidth = 34
+
+// layout sizes the editor to the window
+func (m *playgroundModel) layout() {
+	m.prompt.SetWidth(max(20, m.width-paramsWidth-8))
+}
+
+func (m playgroundModel) outputHeight() int {
+	// Title, editor panels, output borders and footer
+	return max(3, m.height-1-9-4-2)
+}
+
+func (m playgroundModel) View() string {
+	t := currentTheme
+	panel := func(title string, focused bool, body string, width int) string {
+		style := borderStyle.Width(width)
+		if focused {
+			style = style.BorderForeground(t.Primary)
+		}
+		return style.Render(sectionTitleStyle.UnsetMarginTop().Render(title) + "\n" + body)
+	}
+
+	// Editor: prompt on the left, parameters on the right
+	var params strings.Builder
+	labels := [fieldCount]string{
+		fieldModels:      "Models",
+		fieldTemperature: "Temperature",
+		fieldMaxTokens:   "Max tokens",
+		fieldSystem:      "System",
+	}
+	for f := fieldModels; f < fieldCount; f++ {
+		label := labelStyle
+		if m.focus == f {
+			label = label.Foreground(t.Primary).Bold(true)
+		}
+		params.WriteString(label.Render(fmt.Sprintf("%-12s", labels[f])) + " " + m.params[f].View())
+		if f < fieldCount-1 {
+			params.WriteString("\n")
+		}
+	}
+	editor := lipgloss.JoinHorizontal(lipgloss.Top,
+		panel("Prompt", m.focus == fieldPrompt, m.prompt.View(), m.prompt.Width()+2),
+		panel("Parameters", m.focus != fieldPrompt, params.String(), paramsWidth-2),
+	)
+
+	var b strings.Builder
+	b.WriteString(headerStyle.Width(m.width).Render(WithIcon(Icon.Microscope, "MODEL PLAYGROUND")))
+	b.WriteString("\n")
+	b.WriteString(editor)
+	b.WriteString("\n")
+	b.WriteString(m.renderColumns())
+	b.WriteString("\n")
+
+	if m.notice != "" {
+		b.WriteString(warningStyle.Render(m.notice) + "  ")
+	}
+	b.WriteString(mutedStyle.Render("ctrl+r run • esc stop • tab next field • pgup/pgdn scroll • ctrl+c quit"))
+	return b.String()
+}
+
+// renderColumns draws each model's output side by side
+func (m playgroundModel) renderColumns() string {
+	height := m.outputHeight()
+	if len(m.columns) == 0 {
+		return borderStyle.Width(m.width - 2).Height(height).Render(
+			mutedStyle.Render("Outputs appear here. List up to 3 models, separated by commas, to compare them side by side."))
+	}
+
+	width := m.width/len(m.columns) - 2
+	var cols []string
+	for _, col := range m.columns {
+		textWidth := width - 2
+		lines := strings.Split(lipgloss.NewStyle().Width(textWidth).Render(col.output.String()), "\n")
+		if col.err != nil && !errors.Is(col.err, context.Canceled) {
+			lines = append(lines, errorStyle.Width(textWidth).Render(WithIcon(Icon.Fail, col.err.Error())))
+		}
+
+		// Follow the end of the output unless scrolled up. The title and
+		// stats take three lines.
+		bodyHeight := height - 3
+		end := max(0, len(lines)-m.scroll)
+		start := max(0, end-bodyHeight)
+		body := strings.Join(lines[start:end], "\n")
+
+		title := sectionTitleStyle.UnsetMarginTop().Render(col.model)
+		if col.running {
+			title += " " + m.spinner.View()
+		}
+		cols = append(cols, borderStyle.Width(width).Height(height).Render(
+			title+"\n"+lipgloss.NewStyle().Height(bodyHeight).Render(body)+"\n"+m.columnStats(col)))
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, cols...)
+}
+
+func (m playgroundModel) columnStats(col *playColumn) string {
+	switch {
+	case col.running:
+		return mutedStyle.Render(fmt.Sprintf("%d chars so far", col.output.Len())) + "\n"
+	case errors.Is(col.err, context.Canceled):
+		return warningStyle.Render("Stopped") + mutedStyle.Render(fmt.Sprintf(" after %dms", col.elapsed.Milliseconds())) + "\n"
+	case col.err != nil:
+		return errorStyle.Render("Failed") + mutedStyle.Render(fmt.Sprintf(" after %dms", col.elapsed.Milliseconds())) + "\n"
+	}
+
+	latency := col.elapsed.Milliseconds()
+	cost := 0.0
+	if col.resp != nil {
+		if col.resp.LatencyMs > 0 {
+			latency = col.resp.LatencyMs
+		}
+		cost = col.resp.Cost
+	}
+	stats := labelStyle.Render("Latency ") + valueStyle.Render(fmt.Sprintf("%dms", latency))
+	if col.firstToken > 0 {
+		stats += labelStyle.Render("  First token ") + valueStyle.Render(fmt.Sprintf("%dms", col.firstToken.Milliseconds()))
+	}
+	return stats + "\n" + labelStyle.Render("Cost ") + valueStyle.Render(fmt.Sprintf("%.4f credits", cost))
+}
+
+// ShowPlayground runs the interactive model playground
+func ShowPlayground(c *client.ThroneClient, opts PlaygroundOptions) error {
+	p := tea.NewProgram(newPlaygroundModel(c, opts), tea.WithAltScreen())
+	if _, err := p.Run(); err != nil {
+		return fmt.Errorf("error running playground: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,237 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"github.com/sovereynai/reign/internal/config"
+)
+
+// Theme is a color palette applied to all dashboard and CLI styles
+type Theme struct {
+	Name     string
+	Primary  lipgloss.Color // Titles and headers
+	Accent   lipgloss.Color // Section titles
+	Label    lipgloss.Color // Field labels and table headers
+	Value    lipgloss.Color // Emphasized values
+	Muted    lipgloss.Color // Secondary text
+	Border   lipgloss.Color // Box borders
+	HeaderBg lipgloss.Color // Header background
+	Success  lipgloss.Color
+	Warning  lipgloss.Color
+	Error    lipgloss.Color
+	Info     lipgloss.Color
+}
+
+// Glyphs holds the icons and drawing characters used in output. Emoji with a
+// variation selector carry a trailing space so they take two cells everywhere.
+type Glyphs struct {
+	Crown, Throne, Robot, Microscope, Credits, Speed, Target, Chart, Growth string
+	Fire, Computer, Package, Globe, Alert, Memo, Speech, Sparkles, Pin      string
+	Network, Disk, Construction, Refresh, Hourglass, Tag, Tip, OK, Fail     string
+	Rocket, Info, Clipboard                                                 string
+
+	Check, Cross, Warn, Idle, Arrow, Bullet string
+	TrendUp, TrendDown                      string
+	StarFull, StarEmpty                     string
+	BarFull, BarEmpty                       rune
+	Spark                                   []rune // Eight levels, lowest first
+	Degrees                                 string
+	Border                                  lipgloss.Border
+	Spinner                                 spinner.Spinner
+}
+
+// Built-in themes
+var (
+	DarkTheme = Theme{
+		Name:     "dark",
+		Primary:  "205",
+		Accent:   "39",
+		Label:    "86",
+		Value:    "255",
+		Muted:    "243",
+		Border:   "240",
+		HeaderBg: "235",
+		Success:  "42",
+		Warning:  "220",
+		Error:    "196",
+		Info:     "117",
+	}
+
+	LightTheme = Theme{
+		Name:     "light",
+		Primary:  "162",
+		Accent:   "25",
+		Label:    "30",
+		Value:    "232",
+		Muted:    "242",
+		Border:   "248",
+		HeaderBg: "254",
+		Success:  "28",
+		Warning:  "130",
+		Error:    "160",
+		Info:     "31",
+	}
+
+	HighContrastTheme = Theme{
+		Name:     "high-contrast",
+		Primary:  "226",
+		Accent:   "51",
+		Label:    "15",
+		Value:    "15",
+		Muted:    "250",
+		Border:   "15",
+		HeaderBg: "0",
+		Success:  "46",
+		Warning:  "226",
+		Error:    "196",
+		Info:     "51",
+	}
+
+	builtinThemes = map[string]Theme{
+		DarkTheme.Name:         DarkTheme,
+		LightTheme.Name:        LightTheme,
+		HighContrastTheme.Name: HighContrastTheme,
+	}
+)
+
+var (
+	unicodeGlyphs = Glyphs{
+		Crown: "👑", Throne: "🏛️ ", Robot: "🤖", Microscope: "🔬", Credits: "💰",
+		Speed: "⚡", Target: "🎯", Chart: "📊", Growth: "📈", Fire: "🔥",
+		Computer: "🖥️ ", Package: "📦", Globe: "🌍", Alert: "⚠️ ", Memo: "📝",
+		Speech: "💬", Sparkles: "✨", Pin: "📍", Network: "🌐", Disk: "💾",
+		Construction: "🚧", Refresh: "🔄", Hourglass: "⏳", Tag: "🔖", Tip: "💡",
+		OK: "✅", Fail: "❌", Rocket: "🚀", Info: "ℹ️ ", Clipboard: "📋",
+
+		Check: "✓", Cross: "✗", Warn: "⚠", Idle: "○", Arrow: "→", Bullet: "•",
+		TrendUp: "▲", TrendDown: "▼",
+		StarFull: "★", StarEmpty: "☆",
+		BarFull: '█', BarEmpty: '░',
+		Spark:   []rune("▁▂▃▄▅▆▇█"),
+		Degrees: "°C",
+		Border:  lipgloss.RoundedBorder(),
+		Spinner: spinner.Dot,
+	}
+
+	// asciiGlyphs drops decorative emoji and keeps status markers readable
+	asciiGlyphs = Glyphs{
+		OK: "[ok]", Fail: "[x]",
+
+		Check: "+", Cross: "x", Warn: "!", Idle: "o", Arrow: "->", Bullet: "*",
+		TrendUp: "^", TrendDown: "v",
+		StarFull: "*", StarEmpty: ".",
+		BarFull: '#', BarEmpty: '-',
+		Spark:   []rune("._-~=+*#"),
+		Degrees: "C",
+		Border: lipgloss.Border{
+			Top: "-", Bottom: "-", Left: "|", Right: "|",
+			TopLeft: "+", TopRight: "+", BottomLeft: "+", BottomRight: "+",
+			MiddleLeft: "+", MiddleRight: "+", Middle: "+", MiddleTop: "+", MiddleBottom: "+",
+		},
+		Spinner: spinner.Line,
+	}
+)
+
+var (
+	currentTheme = DarkTheme
+
+	// Icon is the active glyph set; use WithIcon to prefix text with an icon
+	Icon = unicodeGlyphs
+)
+
+// CurrentTheme returns the active theme
+func CurrentTheme() Theme {
+	return currentTheme
+}
+
+// ThemeNames lists the built-in and custom theme names
+func ThemeNames(custom map[string]config.ThemeColors) []string {
+	var names []string
+	for name := range builtinThemes {
+		names = append(names, name)
+	}
+	for name := range custom {
+		if _, ok := builtinThemes[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LookupTheme resolves a theme by name, checking custom themes before the
+// built-in ones. An empty name selects the dark theme.
+func LookupTheme(name string, custom map[string]config.ThemeColors) (Theme, error) {
+	if name == "" {
+		return DarkTheme, nil
+	}
+
+	if c, ok := custom[name]; ok {
+		base := DarkTheme
+		if c.Base != "" {
+			b, ok := builtinThemes[c.Base]
+			if !ok {
+				return Theme{}, fmt.Errorf("theme %q: unknown base theme %q", name, c.Base)
+			}
+			base = b
+		}
+		t := base
+		t.Name = name
+		override(&t.Primary, c.Primary)
+		override(&t.Accent, c.Accent)
+		override(&t.Label, c.Label)
+		override(&t.Value, c.Value)
+		override(&t.Muted, c.Muted)
+		override(&t.Border, c.Border)
+		override(&t.HeaderBg, c.HeaderBg)
+		override(&t.Success, c.Success)
+		override(&t.Warning, c.Warning)
+		override(&t.Error, c.Error)
+		override(&t.Info, c.Info)
+		return t, nil
+	}
+
+	if t, ok := builtinThemes[name]; ok {
+		return t, nil
+	}
+	return Theme{}, fmt.Errorf("unknown theme %q (available: %v)", name, ThemeNames(custom))
+}
+
+func override(dst *lipgloss.Color, value string) {
+	if value != "" {
+		*dst = lipgloss.Color(value)
+	}
+}
+
+// UseTheme makes t the active theme and rebuilds all styles
+func UseTheme(t Theme) {
+	currentTheme = t
+	buildStyles()
+}
+
+// UseASCII switches between emoji/box-drawing glyphs and plain ASCII
+func UseASCII(ascii bool) {
+	if ascii {
+		Icon = asciiGlyphs
+	} else {
+		Icon = unicodeGlyphs
+	}
+	buildStyles()
+}
+
+// DisableColor strips colors and text attributes from all output (NO_COLOR)
+func DisableColor() {
+	lipgloss.SetColorProfile(termenv.Ascii)
+}
+
+// WithIcon prefixes text with an icon, omitting the icon when it is empty
+func WithIcon(icon, text string) string {
+	if icon == "" {
+		return text
+	}
+	return icon + " " + text
+}